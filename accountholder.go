@@ -0,0 +1,262 @@
+package starling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// AccountHolderType describes the kind of customer that holds an account
+type AccountHolderType string
+
+// Types of account holder
+const (
+	AccountHolderIndividual AccountHolderType = "INDIVIDUAL"
+	AccountHolderJoint      AccountHolderType = "JOINT"
+	AccountHolderSoleTrader AccountHolderType = "SOLE_TRADER"
+	AccountHolderBusiness   AccountHolderType = "BUSINESS"
+)
+
+// AccountHolder represents the customer that holds the accounts
+type AccountHolder struct {
+	UID  string            `json:"accountHolderUid"`
+	Type AccountHolderType `json:"accountHolderType"`
+}
+
+// AccountHolder returns the account holder for the current user.
+//
+// Note: AccountHolder uses the v2 API which is still under active development.
+func (c *Client) AccountHolder(ctx context.Context) (*AccountHolder, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account-holder", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ah *AccountHolder
+	resp, err := c.Do(ctx, req, &ah)
+	return ah, resp, err
+}
+
+// accountHolderName is the name of the account holder
+type accountHolderName struct {
+	Name string `json:"accountHolderName"`
+}
+
+// AccountHolderName returns the name of the account holder for the current user. For a
+// joint account this includes both account holders and for a business it is the company
+// name.
+//
+// Note: AccountHolderName uses the v2 API which is still under active development.
+func (c *Client) AccountHolderName(ctx context.Context) (string, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account-holder/name", nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var n accountHolderName
+	resp, err := c.Do(ctx, req, &n)
+	return n.Name, resp, err
+}
+
+// Individual represents the personal details of an individual account holder
+type Individual struct {
+	Title       string `json:"title"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+	DateOfBirth string `json:"dateOfBirth"`
+	Email       string `json:"email"`
+	Phone       string `json:"phone"`
+}
+
+// Individual returns the personal details of an individual or sole trader account holder.
+//
+// Note: Individual uses the v2 API which is still under active development.
+func (c *Client) Individual(ctx context.Context) (*Individual, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account-holder/individual", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ind *Individual
+	resp, err := c.Do(ctx, req, &ind)
+	return ind, resp, err
+}
+
+// JointAccountHolders represents the two individuals that hold a joint account
+type JointAccountHolders struct {
+	UID       string     `json:"accountHolderUid"`
+	PersonOne Individual `json:"personOne"`
+	PersonTwo Individual `json:"personTwo"`
+}
+
+// JointAccountHolders returns the individuals that hold a joint account.
+//
+// Note: JointAccountHolders uses the v2 API which is still under active development.
+func (c *Client) JointAccountHolders(ctx context.Context) (*JointAccountHolders, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account-holder/joint", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var j *JointAccountHolders
+	resp, err := c.Do(ctx, req, &j)
+	return j, resp, err
+}
+
+// SoleTrader represents the business details of a sole trader account holder
+type SoleTrader struct {
+	Individual
+	TradingAsName       string `json:"tradingAsName"`
+	BusinessCategory    string `json:"businessCategory"`
+	BusinessSubCategory string `json:"businessSubCategory"`
+}
+
+// SoleTrader returns the business details of a sole trader account holder. The personal
+// details of the sole trader are not included and can be retrieved using Individual.
+//
+// Note: SoleTrader uses the v2 API which is still under active development.
+func (c *Client) SoleTrader(ctx context.Context) (*SoleTrader, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account-holder/sole-trader", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var st *SoleTrader
+	resp, err := c.Do(ctx, req, &st)
+	return st, resp, err
+}
+
+// Business represents the details of a business account holder
+type Business struct {
+	CompanyName               string `json:"companyName"`
+	CompanyType               string `json:"companyType"`
+	CompanyCategory           string `json:"companyCategory"`
+	CompanySubCategory        string `json:"companySubCategory"`
+	CompanyRegistrationNumber string `json:"companyRegistrationNumber"`
+	Email                     string `json:"email"`
+	Phone                     string `json:"phone"`
+
+	RegisteredAddress     PostalAddress `json:"-"`
+	CorrespondenceAddress PostalAddress `json:"-"`
+}
+
+// PostalAddress is a postal address as returned by the v2 API
+type PostalAddress struct {
+	Line1       string `json:"line1"`
+	Line2       string `json:"line2"`
+	Line3       string `json:"line3"`
+	PostTown    string `json:"postTown"`
+	PostCode    string `json:"postCode"`
+	CountryCode string `json:"countryCode"`
+}
+
+// Business returns the details of a business account holder. The registered and
+// correspondence addresses are not included and can be retrieved using
+// BusinessRegisteredAddress and BusinessCorrespondenceAddress.
+//
+// Note: Business uses the v2 API which is still under active development.
+func (c *Client) Business(ctx context.Context) (*Business, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account-holder/business", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var b *Business
+	resp, err := c.Do(ctx, req, &b)
+	return b, resp, err
+}
+
+// BusinessRegisteredAddress returns the registered address of a business account holder.
+//
+// Note: BusinessRegisteredAddress uses the v2 API which is still under active development.
+func (c *Client) BusinessRegisteredAddress(ctx context.Context) (*PostalAddress, *http.Response, error) {
+	return c.businessAddress(ctx, "registered-address")
+}
+
+// BusinessCorrespondenceAddress returns the correspondence address of a business account
+// holder.
+//
+// Note: BusinessCorrespondenceAddress uses the v2 API which is still under active
+// development.
+func (c *Client) BusinessCorrespondenceAddress(ctx context.Context) (*PostalAddress, *http.Response, error) {
+	return c.businessAddress(ctx, "correspondence-address")
+}
+
+func (c *Client) businessAddress(ctx context.Context, kind string) (*PostalAddress, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account-holder/business/"+kind, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var addr *PostalAddress
+	resp, err := c.Do(ctx, req, &addr)
+	return addr, resp, err
+}
+
+// AccountHolderDetails describes any type of account holder. Exactly one of Individual,
+// Joint, SoleTrader and Business is set, according to the account holder Type.
+type AccountHolderDetails struct {
+	AccountHolder
+	Name       string
+	Individual *Individual
+	Joint      *JointAccountHolders
+	SoleTrader *SoleTrader
+	Business   *Business
+}
+
+// AccountHolderDetails returns the details of the account holder for the current user,
+// whatever the type of account holder. An error is returned if any of the details cannot be
+// retrieved or the type of account holder is not supported.
+func (c *Client) AccountHolderDetails(ctx context.Context) (*AccountHolderDetails, *http.Response, error) {
+	ah, resp, err := c.AccountHolder(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+	if ah == nil {
+		return nil, resp, errors.New("unable to retrieve account holder: no account holder returned")
+	}
+
+	d := &AccountHolderDetails{AccountHolder: *ah}
+	d.Name, resp, err = c.AccountHolderName(ctx)
+	if err != nil {
+		return nil, resp, errors.Wrap(err, "unable to retrieve account holder name")
+	}
+
+	switch ah.Type {
+	case AccountHolderIndividual:
+		d.Individual, resp, err = c.Individual(ctx)
+	case AccountHolderJoint:
+		d.Joint, resp, err = c.JointAccountHolders(ctx)
+	case AccountHolderSoleTrader:
+		d.SoleTrader, resp, err = c.SoleTrader(ctx)
+		if err == nil && d.SoleTrader != nil {
+			var ind *Individual
+			ind, resp, err = c.Individual(ctx)
+			if ind != nil {
+				d.SoleTrader.Individual = *ind
+			}
+		}
+	case AccountHolderBusiness:
+		d.Business, resp, err = c.Business(ctx)
+		if err == nil && d.Business != nil {
+			var addr *PostalAddress
+			if addr, resp, err = c.BusinessRegisteredAddress(ctx); addr != nil {
+				d.Business.RegisteredAddress = *addr
+			}
+			if err == nil {
+				if addr, resp, err = c.BusinessCorrespondenceAddress(ctx); addr != nil {
+					d.Business.CorrespondenceAddress = *addr
+				}
+			}
+		}
+	default:
+		return nil, resp, fmt.Errorf("unsupported account holder type %q", ah.Type)
+	}
+
+	if err != nil {
+		return nil, resp, errors.Wrap(err, "unable to retrieve account holder details")
+	}
+	return d, resp, nil
+}
@@ -0,0 +1,157 @@
+package starling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAccountHolder(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/account-holder", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"accountHolderUid": "0e3a4b9c-8f0e-4c1d-9a2b-5d6e7f8a9b0c", "accountHolderType": "SOLE_TRADER"}`)
+	})
+
+	got, _, err := client.AccountHolder(context.Background())
+	checkNoError(t, err)
+
+	want := &AccountHolder{UID: "0e3a4b9c-8f0e-4c1d-9a2b-5d6e7f8a9b0c", Type: AccountHolderSoleTrader}
+	if *got != *want {
+		t.Error("should return an account holder matching the mock response", cross, got)
+	}
+}
+
+func TestAccountHolderForbidden(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/account-holder", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	got, resp, err := client.AccountHolder(context.Background())
+	checkHasError(t, err)
+	checkStatus(t, resp, http.StatusForbidden)
+
+	if got != nil {
+		t.Error("should not return an account holder", cross)
+	}
+}
+
+// accountHolderMocks holds mock responses for the account holder endpoints
+var accountHolderMocks = map[string]string{
+	"/api/v2/account-holder/name":                            `{"accountHolderName": "Ada Lovelace"}`,
+	"/api/v2/account-holder/individual":                      `{"title": "Ms", "firstName": "Ada", "lastName": "Lovelace", "dateOfBirth": "1815-12-10"}`,
+	"/api/v2/account-holder/joint":                           `{"accountHolderUid": "joint", "personOne": {"firstName": "Ada"}, "personTwo": {"firstName": "Charles"}}`,
+	"/api/v2/account-holder/sole-trader":                     `{"tradingAsName": "Analytical Engines", "businessCategory": "ENGINEERING"}`,
+	"/api/v2/account-holder/business":                        `{"companyName": "Analytical Engines Ltd", "companyRegistrationNumber": "01234567"}`,
+	"/api/v2/account-holder/business/registered-address":     `{"line1": "1 Registered Street", "postTown": "London", "postCode": "W1 1AA", "countryCode": "GB"}`,
+	"/api/v2/account-holder/business/correspondence-address": `{"line1": "2 Correspondence Road", "postTown": "London", "postCode": "W1 2BB", "countryCode": "GB"}`,
+}
+
+var accountHolderDetailsTC = []struct {
+	name  string
+	typ   AccountHolderType
+	check func(t *testing.T, d *AccountHolderDetails)
+}{
+	{
+		name: "individual",
+		typ:  AccountHolderIndividual,
+		check: func(t *testing.T, d *AccountHolderDetails) {
+			if d.Individual == nil || d.Individual.LastName != "Lovelace" {
+				t.Error("should include the individual details", cross, d.Individual)
+			}
+		},
+	},
+	{
+		name: "joint",
+		typ:  AccountHolderJoint,
+		check: func(t *testing.T, d *AccountHolderDetails) {
+			if d.Joint == nil || d.Joint.PersonOne.FirstName != "Ada" || d.Joint.PersonTwo.FirstName != "Charles" {
+				t.Error("should include both joint account holders", cross, d.Joint)
+			}
+		},
+	},
+	{
+		name: "sole trader",
+		typ:  AccountHolderSoleTrader,
+		check: func(t *testing.T, d *AccountHolderDetails) {
+			if d.SoleTrader == nil || d.SoleTrader.TradingAsName != "Analytical Engines" || d.SoleTrader.FirstName != "Ada" {
+				t.Error("should include the sole trader and individual details", cross, d.SoleTrader)
+			}
+		},
+	},
+	{
+		name: "business",
+		typ:  AccountHolderBusiness,
+		check: func(t *testing.T, d *AccountHolderDetails) {
+			if d.Business == nil || d.Business.CompanyRegistrationNumber != "01234567" {
+				t.Fatal("should include the business details", cross, d.Business)
+			}
+
+			if d.Business.RegisteredAddress.Line1 != "1 Registered Street" || d.Business.CorrespondenceAddress.PostCode != "W1 2BB" {
+				t.Error("should include the business addresses", cross, d.Business)
+			}
+		},
+	},
+}
+
+func TestAccountHolderDetails(t *testing.T) {
+	for _, tc := range accountHolderDetailsTC {
+		t.Run(tc.name, func(st *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/api/v2/account-holder", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, `{"accountHolderUid": "0e3a4b9c-8f0e-4c1d-9a2b-5d6e7f8a9b0c", "accountHolderType": %q}`, tc.typ)
+			})
+
+			for p, mock := range accountHolderMocks {
+				mock := mock
+				mux.HandleFunc(p, func(w http.ResponseWriter, r *http.Request) {
+					checkMethod(st, r, http.MethodGet)
+					fmt.Fprint(w, mock)
+				})
+			}
+
+			got, _, err := client.AccountHolderDetails(context.Background())
+			checkNoError(st, err)
+
+			if got.Type != tc.typ || got.Name != "Ada Lovelace" {
+				st.Error("should include the account holder type and name", cross, got.Type, got.Name)
+			}
+
+			set := 0
+			for _, p := range []bool{got.Individual != nil, got.Joint != nil, got.SoleTrader != nil, got.Business != nil} {
+				if p {
+					set++
+				}
+			}
+			if set != 1 {
+				st.Error("should only include the details for the account holder type", cross, set)
+			}
+
+			tc.check(st, got)
+		})
+	}
+}
+
+func TestAccountHolderDetailsUnsupported(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/account-holder", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"accountHolderUid": "0e3a4b9c-8f0e-4c1d-9a2b-5d6e7f8a9b0c", "accountHolderType": "TRUST"}`)
+	})
+
+	mux.HandleFunc("/api/v2/account-holder/name", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"accountHolderName": "A Trust"}`)
+	})
+
+	_, _, err := client.AccountHolderDetails(context.Background())
+	checkHasError(t, err)
+}
@@ -3,6 +3,11 @@ package starling
 import (
 	"context"
 	"net/http"
+	"strings"
+	"time"
+
+	"github.com/billglover/starling/validation"
+	"github.com/pkg/errors"
 )
 
 // Account represents bank account details
@@ -17,12 +22,40 @@ type Account struct {
 	CreatedAt     string `json:"createdAt"`
 }
 
+// ErrIdentifierMismatch is returned when the IBAN of an account does not match its BIC, sort
+// code or account number
+var ErrIdentifierMismatch = errors.New("IBAN does not match the other identifiers of the account")
+
+// PrintIBAN returns the IBAN of the account in print format, grouped in fours.
+func (a Account) PrintIBAN() string {
+	return validation.PrintIBAN(a.IBAN)
+}
+
+// Validate checks the IBAN and BIC of the account and, for a GB IBAN, that it matches the
+// BIC, sort code and account number.
+func (a Account) Validate() error {
+	return checkIdentifiers(a.IBAN, a.BIC, a.SortCode, a.AccountNumber)
+}
+
+// AccountType describes the kind of account
+type AccountType string
+
+// Types of account
+const (
+	AccountTypePrimary          AccountType = "PRIMARY"
+	AccountTypeAdditional       AccountType = "ADDITIONAL"
+	AccountTypeLoan             AccountType = "LOAN"
+	AccountTypeFixedTermDeposit AccountType = "FIXED_TERM_DEPOSIT"
+)
+
 // AccountSummary represents the basic account details
 type AccountSummary struct {
-	UID             string `json:"accountUid"`
-	DefaultCategory string `json:"defaultCategory"`
-	Currency        string `json:"currency"`
-	CreatedAt       string `json:"createdAt"`
+	UID             string      `json:"accountUid"`
+	AccountType     AccountType `json:"accountType"`
+	DefaultCategory string      `json:"defaultCategory"`
+	Currency        string      `json:"currency"`
+	CreatedAt       time.Time   `json:"createdAt"`
+	Name            string      `json:"name"`
 }
 
 // Accounts is a list containing all accounts for a customer
@@ -68,6 +101,43 @@ type AccountID struct {
 	BIC    string `json:"bic"`
 }
 
+// PrintIBAN returns the IBAN of the account in print format, grouped in fours.
+func (id AccountID) PrintIBAN() string {
+	return validation.PrintIBAN(id.IBAN)
+}
+
+// Validate checks the IBAN and BIC of the account and, for a GB IBAN, that it matches the
+// BIC, sort code and account number.
+func (id AccountID) Validate() error {
+	return checkIdentifiers(id.IBAN, id.BIC, id.BankID, id.ID)
+}
+
+// checkIdentifiers checks the IBAN and BIC of an account and, for a GB IBAN, that it
+// matches the BIC, sort code and account number.
+func checkIdentifiers(iban, bic, sortCode, accountNumber string) error {
+	if err := validation.CheckIBAN(iban); err != nil {
+		return err
+	}
+
+	if err := validation.CheckBIC(bic); err != nil {
+		return err
+	}
+
+	sc, acc, err := validation.GBAccount(iban)
+	if err == validation.ErrNotGBIBAN {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	e := validation.ElectronicIBAN(iban)
+	if e[4:8] != strings.ToUpper(strings.TrimSpace(bic)[:4]) || sc != strings.Replace(sortCode, "-", "", -1) || acc != accountNumber {
+		return ErrIdentifierMismatch
+	}
+	return nil
+}
+
 // AccountID returns the identifiers for an individual account
 func (c *Client) AccountID(ctx context.Context, uid string) (*AccountID, *http.Response, error) {
 	req, err := c.NewRequest("GET", "/api/v2/accounts/"+uid+"/identifiers", nil)
@@ -80,6 +150,69 @@ func (c *Client) AccountID(ctx context.Context, uid string) (*AccountID, *http.R
 	return actID, resp, err
 }
 
+// AccountView combines the details, identifiers and account holder of an account
+type AccountView struct {
+	AccountSummary
+	AccountHolderUID string
+	Identifiers      AccountID
+}
+
+// AccountViews is a list of account views
+type AccountViews []AccountView
+
+// AccountViews returns the accounts held by the current user along with their identifiers
+// and account holder. An error is returned if the identifiers for any account cannot be
+// retrieved.
+func (c *Client) AccountViews(ctx context.Context) (AccountViews, *http.Response, error) {
+	acts, resp, err := c.Accounts(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	ah, resp, err := c.AccountHolder(ctx)
+	if err != nil {
+		return nil, resp, errors.Wrap(err, "unable to retrieve account holder")
+	}
+
+	views := make(AccountViews, len(acts))
+	for i, act := range acts {
+		ids, resp, err := c.AccountID(ctx, act.UID)
+		if err != nil {
+			return nil, resp, errors.Wrap(err, "unable to retrieve identifiers for account "+act.UID)
+		}
+
+		views[i] = AccountView{AccountSummary: act}
+		if ah != nil {
+			views[i].AccountHolderUID = ah.UID
+		}
+		if ids != nil {
+			views[i].Identifiers = *ids
+		}
+	}
+	return views, resp, nil
+}
+
+// Currency returns the accounts held in the given currency.
+func (vs AccountViews) Currency(currency string) AccountViews {
+	matched := AccountViews{}
+	for _, v := range vs {
+		if v.Currency == currency {
+			matched = append(matched, v)
+		}
+	}
+	return matched
+}
+
+// Primary returns the primary account. False is returned if there is no primary account.
+func (vs AccountViews) Primary() (AccountView, bool) {
+	for _, v := range vs {
+		if v.AccountType == AccountTypePrimary {
+			return v, true
+		}
+	}
+	return AccountView{}, false
+}
+
 // Balance represents the balance on an account
 type Balance struct {
 	Cleared     float64 `json:"clearedBalance"`
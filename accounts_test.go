@@ -8,6 +8,9 @@ import (
 	"path"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/billglover/starling/validation"
 )
 
 var accountsTC = []struct {
@@ -22,9 +25,11 @@ var accountsTC = []struct {
 					"accounts": [
 				 		{
 							"accountUid": "24492cc9-77dd-4155-87a2-ec2580daf139",
+							"accountType": "PRIMARY",
 							"defaultCategory": "8d8c0f3b-f685-49ed-835e-db2ff8cef703",
 							"currency": "GBP",
-							"createdAt": "2017-05-24T07:43:46.664Z"
+							"createdAt": "2017-05-24T07:43:46.664Z",
+							"name": "Personal"
 						}
 					]
 				}`,
@@ -36,12 +41,14 @@ var accountsTC = []struct {
 					"accounts": [
 				 		{
 							"accountUid": "24492cc9-77dd-4155-87a2-ec2580daf139",
+							"accountType": "PRIMARY",
 							"defaultCategory": "8d8c0f3b-f685-49ed-835e-db2ff8cef703",
 							"currency": "GBP",
 							"createdAt": "2017-05-24T07:43:46.664Z"
 						},
 						{
 							"accountUid": "654BB6AB-3C10-49C2-9D4E-D49968772BB0",
+							"accountType": "ADDITIONAL",
 							"defaultCategory": "09e7e421-1afc-483a-98be-0b9da90f9a57",
 							"currency": "GBP",
 							"createdAt": "2017-05-24T07:43:46.664Z"
@@ -375,3 +382,141 @@ func TestBalanceForbidden(t *testing.T) {
 		t.Error("should not return an account")
 	}
 }
+
+const accountViewsMock = `{
+	"accounts": [
+		{
+			"accountUid": "24492cc9-77dd-4155-87a2-ec2580daf139",
+			"accountType": "PRIMARY",
+			"defaultCategory": "8d8c0f3b-f685-49ed-835e-db2ff8cef703",
+			"currency": "GBP",
+			"createdAt": "2017-05-24T07:43:46.664Z",
+			"name": "Personal"
+		},
+		{
+			"accountUid": "654BB6AB-3C10-49C2-9D4E-D49968772BB0",
+			"accountType": "ADDITIONAL",
+			"defaultCategory": "09e7e421-1afc-483a-98be-0b9da90f9a57",
+			"currency": "EUR",
+			"createdAt": "2017-05-24T07:43:46.664Z",
+			"name": "Euro"
+		}
+	]
+}`
+
+func TestAccountViews(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/accounts", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, accountViewsMock)
+	})
+
+	mux.HandleFunc("/api/v2/account-holder", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"accountHolderUid": "0e3a4b9c-8f0e-4c1d-9a2b-5d6e7f8a9b0c", "accountHolderType": "INDIVIDUAL"}`)
+	})
+
+	mux.HandleFunc("/api/v2/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodGet)
+		fmt.Fprintf(w, `{"accountIdentifier": "%s", "bankIdentifier": "608371"}`, path.Base(path.Dir(r.URL.Path))[:8])
+	})
+
+	got, _, err := client.AccountViews(context.Background())
+	checkNoError(t, err)
+
+	if len(got) != 2 {
+		t.Fatal("should return a view for each account", cross, len(got))
+	}
+
+	for _, v := range got {
+		if v.AccountHolderUID != "0e3a4b9c-8f0e-4c1d-9a2b-5d6e7f8a9b0c" {
+			t.Error("should include the account holder", cross, v.AccountHolderUID)
+		}
+
+		if v.Identifiers.ID != v.UID[:8] {
+			t.Error("should include the identifiers for the account", cross, v.Identifiers.ID)
+		}
+	}
+
+	if want := time.Date(2017, 5, 24, 7, 43, 46, 664000000, time.UTC); !got[0].CreatedAt.Equal(want) {
+		t.Error("should parse the account creation time", cross, got[0].CreatedAt)
+	}
+
+	p, ok := got.Primary()
+	if !ok || p.UID != "24492cc9-77dd-4155-87a2-ec2580daf139" {
+		t.Error("should return the primary account", cross, p.UID)
+	}
+
+	eur := got.Currency("EUR")
+	if len(eur) != 1 || eur[0].AccountType != AccountTypeAdditional || eur[0].Name != "Euro" {
+		t.Error("should return the accounts held in a currency", cross, eur)
+	}
+}
+
+var accountValidateTC = []struct {
+	name string
+	id   AccountID
+	want error
+}{
+	{
+		name: "matching identifiers",
+		id:   AccountID{ID: "12345678", BankID: "608371", IBAN: "GB17SRLG60837112345678", BIC: "SRLGGB2L"},
+	},
+	{
+		name: "sort code with dashes",
+		id:   AccountID{ID: "12345678", BankID: "60-83-71", IBAN: "GB17 SRLG 6083 7112 3456 78", BIC: "SRLGGB2L"},
+	},
+	{
+		name: "account number does not match",
+		id:   AccountID{ID: "12345679", BankID: "608371", IBAN: "GB17SRLG60837112345678", BIC: "SRLGGB2L"},
+		want: ErrIdentifierMismatch,
+	},
+	{
+		name: "bank code does not match",
+		id:   AccountID{ID: "12345678", BankID: "608371", IBAN: "GB17SRLG60837112345678", BIC: "NWBKGB2L"},
+		want: ErrIdentifierMismatch,
+	},
+	{
+		name: "invalid IBAN",
+		id:   AccountID{ID: "12345678", BankID: "608371", IBAN: "GB18SRLG60837112345678", BIC: "SRLGGB2L"},
+		want: validation.ErrIBANChecksum,
+	},
+	{
+		name: "invalid BIC",
+		id:   AccountID{ID: "12345678", BankID: "608371", IBAN: "GB17SRLG60837112345678", BIC: "SRLG"},
+		want: validation.ErrInvalidBIC,
+	},
+	{
+		name: "IBAN from another country",
+		id:   AccountID{IBAN: "DE89370400440532013000", BIC: "COBADEFFXXX"},
+	},
+}
+
+func TestAccountIDValidate(t *testing.T) {
+	for _, tc := range accountValidateTC {
+		t.Run(tc.name, func(st *testing.T) {
+			if got := tc.id.Validate(); got != tc.want {
+				st.Error("should validate the account identifiers", cross, got)
+			}
+
+			act := Account{AccountNumber: tc.id.ID, SortCode: tc.id.BankID, IBAN: tc.id.IBAN, BIC: tc.id.BIC}
+			if got := act.Validate(); got != tc.want {
+				st.Error("should validate the account details", cross, got)
+			}
+		})
+	}
+}
+
+func TestPrintIBAN(t *testing.T) {
+	id := AccountID{IBAN: "GB17SRLG60837112345678"}
+	if got := id.PrintIBAN(); got != "GB17 SRLG 6083 7112 3456 78" {
+		t.Error("should format the IBAN for print", cross, got)
+	}
+
+	act := Account{IBAN: id.IBAN}
+	if got := act.PrintIBAN(); got != "GB17 SRLG 6083 7112 3456 78" {
+		t.Error("should format the IBAN for print", cross, got)
+	}
+}
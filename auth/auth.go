@@ -0,0 +1,307 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/billglover/starling"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// SandboxEndpoint is the OAuth endpoint for the sandbox instance of the Starling API
+var SandboxEndpoint = oauth2.Endpoint{
+	AuthURL:   "https://oauth-sandbox.starlingbank.com/",
+	TokenURL:  starling.SandboxURL + "oauth/access-token",
+	AuthStyle: oauth2.AuthStyleInParams,
+}
+
+// ProdEndpoint is the OAuth endpoint for the production instance of the Starling API
+var ProdEndpoint = oauth2.Endpoint{
+	AuthURL:   "https://oauth.starlingbank.com/",
+	TokenURL:  starling.ProdURL + "oauth/access-token",
+	AuthStyle: oauth2.AuthStyleInParams,
+}
+
+// EndpointFor returns the OAuth endpoint for the API at baseURL. The sandbox endpoint is
+// returned for any URL other than ProdURL.
+func EndpointFor(baseURL string) oauth2.Endpoint {
+	if baseURL == starling.ProdURL {
+		return ProdEndpoint
+	}
+	return SandboxEndpoint
+}
+
+// Config describes a Starling OAuth client application.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+
+	// RedirectURL must be a loopback http URL registered with the application. A listener
+	// is started on its host and port to receive the authorisation code. A port of 0
+	// selects a free port.
+	RedirectURL string
+
+	// BaseURL is the API the tokens are for, either starling.SandboxURL or
+	// starling.ProdURL. The sandbox is used if it is empty.
+	BaseURL string
+
+	// Endpoint overrides the endpoint selected by BaseURL.
+	Endpoint *oauth2.Endpoint
+
+	// Store persists tokens between runs. Tokens are not persisted if it is nil.
+	Store TokenStore
+
+	// Open is called with the URL of the Starling authorisation page. If it is nil the URL
+	// is printed to stderr for the user to visit.
+	Open func(url string) error
+}
+
+// Client returns an HTTP client that authenticates requests to the Starling API. A token
+// is loaded from the store if one has been saved, otherwise the authorisation flow is run.
+// Refreshed tokens are saved to the store.
+func (c *Config) Client(ctx context.Context) (*http.Client, error) {
+	var tok *oauth2.Token
+	var err error
+
+	if c.Store != nil {
+		tok, err = c.Store.Load()
+		if err != nil && err != ErrNoToken {
+			return nil, errors.Wrap(err, "unable to load token")
+		}
+	}
+
+	if tok == nil {
+		tok, err = c.Authorize(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &http.Client{Transport: &oauth2.Transport{Source: c.TokenSource(ctx, tok)}}, nil
+}
+
+// Authorize runs the authorisation code flow. The user is sent to the Starling
+// authorisation page and the code returned to the redirect listener is exchanged for a
+// token. The token is saved to the store. An error is returned if the user denies access,
+// the state returned does not match, or the context is cancelled before the code arrives.
+func (c *Config) Authorize(ctx context.Context) (*oauth2.Token, error) {
+	redirect, err := url.Parse(c.RedirectURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse redirect URL")
+	}
+
+	if redirect.Scheme != "http" || !isLoopback(redirect.Hostname()) {
+		return nil, fmt.Errorf("redirect URL must be a loopback http URL: %q", c.RedirectURL)
+	}
+
+	l, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to start redirect listener")
+	}
+	if redirect.Path == "" {
+		redirect.Path = "/"
+	}
+	redirect.Host = net.JoinHostPort(redirect.Hostname(), fmt.Sprint(l.Addr().(*net.TCPAddr).Port))
+
+	state, err := randomString(16)
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	verifier, err := newVerifier()
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+
+	codes := make(chan string, 1)
+	errs := make(chan error, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirect.Path, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != redirect.Path {
+			// Ignore requests such as /favicon.ico when the redirect is mounted on "/".
+			http.NotFound(w, r)
+			return
+		}
+
+		q := r.URL.Query()
+		switch {
+		case q.Get("state") != state:
+			http.Error(w, "Invalid state.", http.StatusBadRequest)
+			send(errs, errors.New("authorisation state does not match"))
+		case q.Get("error") != "":
+			http.Error(w, "Authorisation failed.", http.StatusForbidden)
+			send(errs, fmt.Errorf("authorisation failed: %s", q.Get("error")))
+		case q.Get("code") == "":
+			http.Error(w, "Missing authorisation code.", http.StatusBadRequest)
+			send(errs, errors.New("authorisation code missing from redirect"))
+		default:
+			fmt.Fprintln(w, "Authorisation complete. You may close this window.")
+			select {
+			case codes <- q.Get("code"):
+			default:
+			}
+		}
+	})
+
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(l)
+	defer srv.Close()
+
+	oc := c.oauth2Config(redirect.String())
+	authURL := oc.AuthCodeURL(state,
+		oauth2.SetAuthURLParam("code_challenge", challenge(verifier)),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	)
+
+	open := c.Open
+	if open == nil {
+		open = func(u string) error {
+			_, err := fmt.Fprintf(os.Stderr, "Visit the following URL to authorise access:\n\n%s\n\n", u)
+			return err
+		}
+	}
+
+	if err := open(authURL); err != nil {
+		return nil, errors.Wrap(err, "unable to open authorisation page")
+	}
+
+	var code string
+	select {
+	case code = <-codes:
+	case err := <-errs:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	tok, err := oc.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", verifier))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to exchange authorisation code")
+	}
+
+	if c.Store != nil {
+		if err := c.Store.Save(tok); err != nil {
+			return nil, errors.Wrap(err, "unable to save token")
+		}
+	}
+
+	return tok, nil
+}
+
+// TokenSource returns a TokenSource that returns tok until it expires and then refreshes
+// it. Refreshed tokens are saved to the store.
+func (c *Config) TokenSource(ctx context.Context, tok *oauth2.Token) *TokenSource {
+	return &TokenSource{
+		ctx:   ctx,
+		conf:  c.oauth2Config(c.RedirectURL),
+		store: c.Store,
+		tok:   tok,
+	}
+}
+
+func (c *Config) oauth2Config(redirectURL string) *oauth2.Config {
+	ep := EndpointFor(c.BaseURL)
+	if c.Endpoint != nil {
+		ep = *c.Endpoint
+	}
+
+	return &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		Endpoint:     ep,
+		RedirectURL:  redirectURL,
+		Scopes:       c.Scopes,
+	}
+}
+
+// TokenSource is an oauth2.TokenSource that saves tokens to a store whenever they are
+// refreshed. It implements starling.TokenRefresher so that a Client can refresh the token
+// before it expires.
+type TokenSource struct {
+	ctx   context.Context
+	conf  *oauth2.Config
+	store TokenStore
+
+	mu  sync.Mutex
+	tok *oauth2.Token
+}
+
+// Token returns the current token, refreshing it if it has expired.
+func (s *TokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tok.Valid() {
+		return s.tok, nil
+	}
+	return s.refresh(s.ctx)
+}
+
+// RefreshToken refreshes the token whether or not it has expired.
+func (s *TokenSource) RefreshToken(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.refresh(ctx)
+	return err
+}
+
+// Tenant returns the credentials for a customer in a starling.ClientPool. Requests are
+// authenticated with tokens from s and the token is refreshed before it expires.
+func (s *TokenSource) Tenant() *starling.Tenant {
+	return &starling.Tenant{
+		Transport: func(base http.RoundTripper) http.RoundTripper {
+			return &oauth2.Transport{Source: s, Base: base}
+		},
+		Refresher: s,
+	}
+}
+
+// refresh exchanges the refresh token for a new token and saves it. The caller must hold
+// s.mu.
+func (s *TokenSource) refresh(ctx context.Context) (*oauth2.Token, error) {
+	if s.tok == nil || s.tok.RefreshToken == "" {
+		return nil, errors.New("unable to refresh token: no refresh token available")
+	}
+
+	tok, err := s.conf.TokenSource(ctx, &oauth2.Token{RefreshToken: s.tok.RefreshToken}).Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to refresh token")
+	}
+
+	if s.store != nil {
+		if err := s.store.Save(tok); err != nil {
+			return nil, errors.Wrap(err, "unable to save refreshed token")
+		}
+	}
+
+	s.tok = tok
+	return tok, nil
+}
+
+// send sends err on errs unless an earlier error is waiting to be received.
+func send(errs chan<- error, err error) {
+	select {
+	case errs <- err:
+	default:
+	}
+}
+
+// isLoopback reports whether host is localhost or a loopback IP address.
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,343 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+	"golang.org/x/oauth2"
+)
+
+const (
+	tick  = "\u2713"
+	cross = "\u2717"
+)
+
+// tokenServer returns a token endpoint that issues access tokens in sequence. The form
+// values of the most recent request are passed to check.
+func tokenServer(t *testing.T, check func(url.Values)) *httptest.Server {
+	n := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Error("should exchange tokens using POST", cross, r.Method)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal("should send a form", cross, err)
+		}
+		if r.PostForm.Get("client_id") != "client" || r.PostForm.Get("client_secret") != "secret" {
+			t.Error("should send client credentials in the form", cross, r.PostForm)
+		}
+		check(r.PostForm)
+
+		n++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token": "access-%d", "refresh_token": "refresh-%d", "token_type": "Bearer", "expires_in": 3600}`, n, n)
+	}))
+}
+
+func TestEndpointFor(t *testing.T) {
+	if EndpointFor(starling.ProdURL) != ProdEndpoint {
+		t.Error("should return the production endpoint for the production API", cross)
+	}
+
+	if EndpointFor(starling.SandboxURL) != SandboxEndpoint {
+		t.Error("should return the sandbox endpoint for the sandbox API", cross)
+	}
+
+	if EndpointFor("") != SandboxEndpoint {
+		t.Error("should default to the sandbox endpoint", cross)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	var sentChallenge string
+	server := tokenServer(t, func(v url.Values) {
+		if v.Get("grant_type") != "authorization_code" || v.Get("code") != "the-code" {
+			t.Error("should exchange the authorisation code", cross, v)
+		}
+		if challenge(v.Get("code_verifier")) != sentChallenge {
+			t.Error("should send a code verifier matching the code challenge", cross)
+		}
+	})
+	defer server.Close()
+
+	store := &MemoryStore{}
+	cfg := &Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://127.0.0.1:0/callback",
+		Endpoint:     &oauth2.Endpoint{AuthURL: "https://oauth.example.com/", TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams},
+		Store:        store,
+		Open: func(s string) error {
+			u, err := url.Parse(s)
+			if err != nil {
+				return err
+			}
+
+			q := u.Query()
+			if q.Get("code_challenge_method") != "S256" {
+				t.Error("should request an S256 code challenge", cross, q.Get("code_challenge_method"))
+			}
+			sentChallenge = q.Get("code_challenge")
+
+			resp, err := http.Get(q.Get("redirect_uri") + "?code=the-code&state=" + q.Get("state"))
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Error("should accept the redirect", cross, resp.StatusCode)
+			}
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tok, err := cfg.Authorize(ctx)
+	if err != nil {
+		t.Fatal("should complete the authorisation flow", cross, err)
+	}
+
+	if tok.AccessToken != "access-1" || tok.RefreshToken != "refresh-1" {
+		t.Error("should return the exchanged token", cross, tok)
+	}
+
+	saved, err := store.Load()
+	if err != nil || saved.AccessToken != "access-1" {
+		t.Error("should save the token to the store", cross, saved, err)
+	}
+}
+
+func TestAuthorizeStateMismatch(t *testing.T) {
+	cfg := &Config{
+		RedirectURL: "http://localhost:0/callback",
+		Endpoint:    &oauth2.Endpoint{AuthURL: "https://oauth.example.com/", TokenURL: "https://oauth.example.com/token"},
+		Open: func(s string) error {
+			u, _ := url.Parse(s)
+			resp, err := http.Get(u.Query().Get("redirect_uri") + "?code=the-code&state=forged")
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := cfg.Authorize(ctx); err == nil || ctx.Err() != nil {
+		t.Error("should reject a redirect with the wrong state", cross, err)
+	}
+}
+
+func TestAuthorizeIgnoresOtherPaths(t *testing.T) {
+	server := tokenServer(t, func(url.Values) {})
+	defer server.Close()
+
+	cfg := &Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "http://127.0.0.1:0",
+		Endpoint:     &oauth2.Endpoint{AuthURL: "https://oauth.example.com/", TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams},
+		Open: func(s string) error {
+			u, _ := url.Parse(s)
+			q := u.Query()
+			redirect, _ := url.Parse(q.Get("redirect_uri"))
+
+			resp, err := http.Get("http://" + redirect.Host + "/favicon.ico")
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusNotFound {
+				t.Error("should not handle requests to other paths", cross, resp.StatusCode)
+			}
+
+			resp, err = http.Get(q.Get("redirect_uri") + "?code=the-code&state=" + q.Get("state"))
+			if err != nil {
+				return err
+			}
+			resp.Body.Close()
+			return nil
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := cfg.Authorize(ctx); err != nil {
+		t.Error("should complete the flow after a request to another path", cross, err)
+	}
+}
+
+func TestAuthorizeRedirectURL(t *testing.T) {
+	for _, u := range []string{"https://127.0.0.1/callback", "http://example.com/callback", "::"} {
+		cfg := &Config{RedirectURL: u, Open: func(string) error { return nil }}
+		if _, err := cfg.Authorize(context.Background()); err == nil {
+			t.Error("should reject a redirect URL that is not a loopback http URL", cross, u)
+		}
+	}
+}
+
+func TestTokenSourceRefresh(t *testing.T) {
+	server := tokenServer(t, func(v url.Values) {
+		if v.Get("grant_type") != "refresh_token" || v.Get("refresh_token") != "refresh-0" {
+			t.Error("should refresh using the refresh token", cross, v)
+		}
+	})
+	defer server.Close()
+
+	store := &MemoryStore{}
+	cfg := &Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Endpoint:     &oauth2.Endpoint{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams},
+		Store:        store,
+	}
+
+	expired := &oauth2.Token{AccessToken: "access-0", RefreshToken: "refresh-0", Expiry: time.Now().Add(-time.Hour)}
+	ts := cfg.TokenSource(context.Background(), expired)
+
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatal("should refresh the token", cross, err)
+	}
+
+	if tok.AccessToken != "access-1" {
+		t.Error("should return the refreshed token", cross, tok.AccessToken)
+	}
+
+	saved, err := store.Load()
+	if err != nil || saved.AccessToken != "access-1" {
+		t.Error("should save the refreshed token", cross, saved, err)
+	}
+}
+
+func TestClientUsesStoredToken(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer stored" {
+			t.Error("should authenticate with the stored token", cross, r.Header.Get("Authorization"))
+		}
+	}))
+	defer api.Close()
+
+	store := &MemoryStore{}
+	store.Save(&oauth2.Token{AccessToken: "stored", Expiry: time.Now().Add(time.Hour)})
+
+	cfg := &Config{
+		Store: store,
+		Open: func(string) error {
+			t.Error("should not run the authorisation flow with a stored token", cross)
+			return nil
+		},
+	}
+
+	hc, err := cfg.Client(context.Background())
+	if err != nil {
+		t.Fatal("should return a client", cross, err)
+	}
+
+	resp, err := hc.Get(api.URL)
+	if err != nil {
+		t.Fatal("should make an authenticated request", cross, err)
+	}
+	resp.Body.Close()
+}
+
+func TestTokenSourceRefreshToken(t *testing.T) {
+	server := tokenServer(t, func(v url.Values) {})
+	defer server.Close()
+
+	cfg := &Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Endpoint:     &oauth2.Endpoint{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+
+	valid := &oauth2.Token{AccessToken: "access-0", RefreshToken: "refresh-0", Expiry: time.Now().Add(time.Hour)}
+	ts := cfg.TokenSource(context.Background(), valid)
+
+	if err := ts.RefreshToken(context.Background()); err != nil {
+		t.Fatal("should refresh the token", cross, err)
+	}
+
+	tok, _ := ts.Token()
+	if tok.AccessToken != "access-1" {
+		t.Error("should refresh a token before it expires", cross, tok.AccessToken)
+	}
+
+	noRefresh := cfg.TokenSource(context.Background(), &oauth2.Token{AccessToken: "access-0"})
+	if err := noRefresh.RefreshToken(context.Background()); err == nil {
+		t.Error("should return an error without a refresh token", cross)
+	}
+}
+
+func TestTokenSourceTenant(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"customerUid": %q}`, r.Header.Get("Authorization"))
+	}))
+	defer api.Close()
+
+	cfg := &Config{}
+	ts := cfg.TokenSource(context.Background(), &oauth2.Token{AccessToken: "alice", Expiry: time.Now().Add(time.Hour)})
+
+	baseURL, _ := url.Parse(api.URL + "/")
+	pool := starling.NewClientPool(starling.PoolOptions{
+		BaseURL: baseURL,
+		Tenant: func(ctx context.Context, uid string) (*starling.Tenant, error) {
+			return ts.Tenant(), nil
+		},
+	})
+
+	c, err := pool.Client(context.Background(), "alice")
+	if err != nil {
+		t.Fatal("should create a client for the customer", cross, err)
+	}
+
+	ident, _, err := c.CurrentUser(context.Background())
+	if err != nil || ident.UID != "Bearer alice" {
+		t.Error("should authenticate requests with the customer token", cross, ident, err)
+	}
+}
+
+func TestTokenSourceRefresherRetry(t *testing.T) {
+	server := tokenServer(t, func(v url.Values) {})
+	defer server.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"customerUid": %q}`, r.Header.Get("Authorization"))
+	}))
+	defer api.Close()
+
+	cfg := &Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Endpoint:     &oauth2.Endpoint{TokenURL: server.URL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+
+	revoked := &oauth2.Token{AccessToken: "access-0", RefreshToken: "refresh-0", Expiry: time.Now().Add(time.Hour)}
+	ts := cfg.TokenSource(context.Background(), revoked)
+
+	baseURL, _ := url.Parse(api.URL + "/")
+	hc := &http.Client{Transport: &oauth2.Transport{Source: ts}}
+	c := starling.NewClientWithOptions(hc, starling.ClientOptions{BaseURL: baseURL, Refresher: ts})
+
+	ident, _, err := c.CurrentUser(context.Background())
+	if err != nil || ident.UID != "Bearer access-1" {
+		t.Error("should retry an unauthorised request with the refreshed token", cross, ident, err)
+	}
+}
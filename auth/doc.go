@@ -0,0 +1,32 @@
+/*
+Package auth obtains OAuth access tokens for the Starling API using the authorization
+code flow with PKCE.
+
+Personal access tokens can be used with oauth2.StaticTokenSource, but applications acting
+on behalf of other Starling customers must send the customer through the Starling
+authorisation pages. Config runs the flow using a loopback redirect listener, exchanges
+the authorisation code for a token and persists it through a TokenStore so that it can be
+refreshed on subsequent runs:
+
+	key := []byte(os.Getenv("TOKEN_KEY")) // 16, 24 or 32 bytes
+	cfg := &auth.Config{
+		ClientID:     "{{CLIENT_ID}}",
+		ClientSecret: "{{CLIENT_SECRET}}",
+		RedirectURL:  "http://localhost:8080/callback",
+		Store:        auth.NewEncryptedStore(auth.File("token.enc"), key),
+	}
+
+	hc, err := cfg.Client(ctx)
+	client := starling.NewClient(hc)
+
+Long-running processes can use a TokenSource as the Refresher for a client so that the
+token is refreshed before it expires and requests rejected as unauthorised are retried. The
+HTTP client must take each token from the TokenSource, rather than from a cached copy such
+as the one oauth2.NewClient wraps it in, so that retried requests use the refreshed token:
+
+	ts := cfg.TokenSource(ctx, tok)
+	hc := &http.Client{Transport: &oauth2.Transport{Source: ts}}
+	opts := starling.ClientOptions{BaseURL: baseURL, Refresher: ts}
+	client := starling.NewClientWithOptions(hc, opts)
+*/
+package auth
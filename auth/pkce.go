@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// verifierLength is the number of random bytes in a PKCE code verifier. Encoded, this
+// gives the 43 character minimum length required by RFC 7636.
+const verifierLength = 32
+
+// newVerifier returns a new random PKCE code verifier.
+func newVerifier() (string, error) {
+	return randomString(verifierLength)
+}
+
+// challenge returns the S256 PKCE code challenge for a code verifier.
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomString returns n cryptographically random bytes encoded as unpadded base64url.
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
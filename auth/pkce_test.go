@@ -0,0 +1,29 @@
+package auth
+
+import "testing"
+
+func TestChallenge(t *testing.T) {
+	// Example from RFC 7636 Appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	if got := challenge(verifier); got != want {
+		t.Error("should return the S256 code challenge", cross, got)
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	v1, err := newVerifier()
+	if err != nil {
+		t.Fatal("should generate a verifier", cross, err)
+	}
+
+	if len(v1) < 43 || len(v1) > 128 {
+		t.Error("should generate a verifier of between 43 and 128 characters", cross, len(v1))
+	}
+
+	v2, _ := newVerifier()
+	if v1 == v2 {
+		t.Error("should generate a different verifier each time", cross)
+	}
+}
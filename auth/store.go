@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// ErrNoToken is returned when no token has been saved
+var ErrNoToken = errors.New("no token stored")
+
+// TokenStore persists tokens between runs. Load returns ErrNoToken if no token has been
+// saved.
+type TokenStore interface {
+	Load() (*oauth2.Token, error)
+	Save(t *oauth2.Token) error
+}
+
+// Backend stores the sealed bytes of a token on behalf of an EncryptedStore. Read returns
+// ErrNoToken if nothing has been written.
+type Backend interface {
+	Read() ([]byte, error)
+	Write(data []byte) error
+}
+
+// File is a Backend that stores data in the named file. The file is only readable by the
+// current user.
+type File string
+
+// Read returns the contents of the file.
+func (f File) Read() ([]byte, error) {
+	data, err := ioutil.ReadFile(string(f))
+	if os.IsNotExist(err) {
+		return nil, ErrNoToken
+	}
+	return data, err
+}
+
+// Write replaces the contents of the file. The data is written to a temporary file which
+// is then renamed so that a failed write does not lose the previous token.
+func (f File) Write(data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(string(f)), filepath.Base(string(f)))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), string(f))
+}
+
+// EncryptedStore is a TokenStore that seals tokens using AES-GCM before passing them to a
+// Backend.
+type EncryptedStore struct {
+	backend Backend
+	key     []byte
+}
+
+// NewEncryptedStore returns a TokenStore that seals tokens with the given key and stores
+// them in b. The key must be 16, 24 or 32 bytes long to select AES-128, AES-192 or
+// AES-256.
+func NewEncryptedStore(b Backend, key []byte) *EncryptedStore {
+	return &EncryptedStore{backend: b, key: key}
+}
+
+// Load reads and opens the stored token. An error is returned if the token cannot be
+// opened with the store key.
+func (s *EncryptedStore) Load() (*oauth2.Token, error) {
+	data, err := s.backend.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := s.aead()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("unable to open token: data too short")
+	}
+
+	nonce, sealed := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open token")
+	}
+
+	t := new(oauth2.Token)
+	if err := json.Unmarshal(plain, t); err != nil {
+		return nil, errors.Wrap(err, "unable to parse token")
+	}
+	return t, nil
+}
+
+// Save seals and writes the token.
+func (s *EncryptedStore) Save(t *oauth2.Token) error {
+	plain, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	aead, err := s.aead()
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	return s.backend.Write(aead.Seal(nonce, nonce, plain, nil))
+}
+
+func (s *EncryptedStore) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid token store key")
+	}
+	return cipher.NewGCM(block)
+}
+
+// MemoryStore is a TokenStore that holds a token in memory. It does not encrypt the token
+// and is intended for testing and short-lived processes.
+type MemoryStore struct {
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// Load returns the stored token.
+func (s *MemoryStore) Load() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil {
+		return nil, ErrNoToken
+	}
+	t := *s.token
+	return &t, nil
+}
+
+// Save stores the token.
+func (s *MemoryStore) Save(t *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := *t
+	s.token = &c
+	return nil
+}
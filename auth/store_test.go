@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+var testKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestEncryptedStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "token.enc")
+	s := NewEncryptedStore(File(path), testKey)
+
+	if _, err := s.Load(); err != ErrNoToken {
+		t.Error("should return ErrNoToken before a token is saved", cross, err)
+	}
+
+	want := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer", Expiry: time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)}
+	if err := s.Save(want); err != nil {
+		t.Fatal("should save the token", cross, err)
+	}
+
+	data, _ := ioutil.ReadFile(path)
+	if bytes.Contains(data, []byte("access")) || bytes.Contains(data, []byte("refresh")) {
+		t.Error("should not store the token in plain text", cross)
+	}
+
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0600 {
+		t.Error("should only allow the owner to read the token file", cross, fi.Mode())
+	}
+
+	got, err := s.Load()
+	if err != nil {
+		t.Fatal("should load the token", cross, err)
+	}
+
+	if got.AccessToken != want.AccessToken || got.RefreshToken != want.RefreshToken || !got.Expiry.Equal(want.Expiry) {
+		t.Error("should load the saved token", cross, got)
+	}
+
+	other := NewEncryptedStore(File(path), []byte("fedcba9876543210fedcba9876543210"))
+	if _, err := other.Load(); err == nil {
+		t.Error("should not open a token sealed with a different key", cross)
+	}
+}
+
+func TestEncryptedStoreInvalidKey(t *testing.T) {
+	s := NewEncryptedStore(&memoryBackend{}, []byte("short"))
+	if err := s.Save(&oauth2.Token{AccessToken: "access"}); err == nil {
+		t.Error("should reject a key of the wrong length", cross)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := &MemoryStore{}
+
+	if _, err := s.Load(); err != ErrNoToken {
+		t.Error("should return ErrNoToken before a token is saved", cross, err)
+	}
+
+	tok := &oauth2.Token{AccessToken: "access"}
+	s.Save(tok)
+	tok.AccessToken = "changed"
+
+	got, err := s.Load()
+	if err != nil || got.AccessToken != "access" {
+		t.Error("should return a copy of the saved token", cross, got, err)
+	}
+}
+
+type memoryBackend struct {
+	data []byte
+}
+
+func (b *memoryBackend) Read() ([]byte, error) {
+	if b.data == nil {
+		return nil, ErrNoToken
+	}
+	return b.data, nil
+}
+
+func (b *memoryBackend) Write(data []byte) error {
+	b.data = data
+	return nil
+}
@@ -0,0 +1,31 @@
+/*
+Package automation moves money into savings goals according to a set of rules, built on
+top of the Starling API client.
+
+Rules plan transfers from new feed items and the current account balance. RoundUp saves
+the change from each outgoing payment, PercentOfIncome saves a share of each incoming
+payment and Sweep moves anything above a threshold into a goal at the end of the day:
+
+	e := &automation.Engine{
+		Client: client,
+		Rules: []automation.Rule{
+			automation.RoundUp{GoalUID: holiday},
+			automation.PercentOfIncome{GoalUID: rainyDay, Percent: 10},
+			automation.Sweep{GoalUID: rainyDay, Threshold: starling.Amount{Currency: "GBP", MinorUnits: 50000}, At: 23 * time.Hour},
+		},
+		Log:   automation.FileLog("automation.log"),
+		Batch: true,
+	}
+	records, err := e.RunFeed(ctx, act, cat, lastRun)
+
+Each planned transfer is given a UID derived from the rule, the goal, the currency and
+the period it was planned for: the start of the feed window passed to RunFeed or, for a
+sweep, the day. Transfers are made using TransferToAccountSavingsGoalWithUID, so retrying
+a run that failed or was interrupted does not move the money twice, even if further feed
+items have arrived since. The Log records the feed items and days each rule has saved
+from, and these are left out when planning, so feed items seen again in a later run, for
+example when runs overlap or a pending payment settles, are not saved from twice. Without
+a Log only a run for the same period is recognised. Set DryRun to see what would be moved
+without making any transfers.
+*/
+package automation
@@ -0,0 +1,238 @@
+package automation
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/billglover/starling"
+	"github.com/pkg/errors"
+)
+
+// Engine plans transfers using a set of rules and makes them using the client. Transfers
+// are made with the UID derived when they were planned, so a transfer that is planned
+// again after a failed or interrupted run is not made twice. If a Log is set, the sources
+// each rule has already saved from are left out when planning, so a feed item seen again
+// in a later run is not saved from twice.
+type Engine struct {
+	Client *starling.Client
+	Rules  []Rule
+	Log    Log  // Records every planned transfer, if set
+	DryRun bool // Plan and log transfers without making them
+	Batch  bool // Combine the transfers planned for the same goal into one
+
+	now func() time.Time
+}
+
+// Plan returns the transfers planned by the rules for the input. Each rule sees the
+// effective balance reduced by the transfers planned by the rules before it.
+func (e *Engine) Plan(in Input) []Transfer {
+	return e.plan(in, nil)
+}
+
+// plan returns the transfers planned by the rules for the input, leaving out the sources
+// in saved.
+func (e *Engine) plan(in Input, saved map[string]bool) []Transfer {
+	if in.Balance != nil {
+		b := *in.Balance
+		in.Balance = &b
+	}
+
+	ts := []Transfer{}
+	for _, r := range e.Rules {
+		planned := planUnsaved(r, in, saved)
+		for _, t := range planned {
+			if in.Balance != nil && in.Balance.Effective.Currency == t.Amount.Currency {
+				in.Balance.Effective.MinorUnits -= t.Amount.MinorUnits
+			}
+		}
+		ts = append(ts, planned...)
+	}
+
+	if e.Batch {
+		ts = batch(ts)
+	}
+	return ts
+}
+
+// sourceKey identifies a source from which a rule has saved into a goal.
+func sourceKey(rule, goal, source string) string {
+	return rule + "/" + goal + "/" + source
+}
+
+// planUnsaved returns the transfers planned by the rule, leaving out the sources in saved
+// from which the rule has already saved into the same goal. Feed items that have been saved
+// from are removed from the input and the rule planned again, so that the transfer is made
+// up of the remaining items only.
+func planUnsaved(r Rule, in Input, saved map[string]bool) []Transfer {
+	planned := r.Plan(in)
+	if len(saved) == 0 {
+		return planned
+	}
+
+	isSaved := func(src string) bool {
+		for _, t := range planned {
+			if saved[sourceKey(t.Rule, t.GoalUID, src)] {
+				return true
+			}
+		}
+		return false
+	}
+
+	items := []starling.Item{}
+	for _, itm := range in.Items {
+		if !isSaved(itm.FeedItemUID) {
+			items = append(items, itm)
+		}
+	}
+
+	if len(items) < len(in.Items) {
+		in.Items = items
+		planned = r.Plan(in)
+	}
+
+	ts := []Transfer{}
+	for _, t := range planned {
+		unsaved := true
+		for _, src := range t.Sources {
+			if saved[sourceKey(t.Rule, t.GoalUID, src)] {
+				unsaved = false
+			}
+		}
+		if unsaved {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+// batch combines transfers into the same goal in the same currency. The combined transfers
+// are returned in the order in which each goal and currency was first planned.
+func batch(ts []Transfer) []Transfer {
+	var keys []string
+	groups := map[string][]Transfer{}
+	for _, t := range ts {
+		k := t.GoalUID + "/" + t.Amount.Currency
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], t)
+	}
+
+	batched := make([]Transfer, len(keys))
+	for i, k := range keys {
+		g := groups[k]
+		if len(g) == 1 {
+			batched[i] = g[0]
+			continue
+		}
+
+		amt := starling.Amount{Currency: g[0].Amount.Currency}
+		seen := map[string]bool{}
+		var rules, sources []string
+		for _, t := range g {
+			amt.MinorUnits += t.Amount.MinorUnits
+			sources = append(sources, t.Sources...)
+			if !seen[t.Rule] {
+				seen[t.Rule] = true
+				rules = append(rules, t.Rule)
+			}
+		}
+		sort.Strings(rules)
+
+		batched[i] = newTransfer(strings.Join(rules, "+"), g[0].GoalUID, g[0].Period, amt, sources)
+		batched[i].Combined = g
+	}
+	return batched
+}
+
+// Run plans the transfers for the input and makes them in order, skipping any that the
+// log records as already moved and leaving out the sources they were planned from. A
+// record of each transfer is appended to the log. Transfers are made from in.Account, or
+// the default account if it is empty. Run stops at the first transfer that fails and
+// returns the records made so far along with the error.
+func (e *Engine) Run(ctx context.Context, in Input) ([]Record, error) {
+	done := map[string]bool{}
+	saved := map[string]bool{}
+	if e.Log != nil {
+		prev, err := e.Log.Records()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range prev {
+			if !r.Moved() {
+				continue
+			}
+			done[r.UID] = true
+			for _, t := range r.parts() {
+				for _, src := range t.Sources {
+					saved[sourceKey(t.Rule, t.GoalUID, src)] = true
+				}
+			}
+		}
+	}
+
+	now := time.Now
+	if e.now != nil {
+		now = e.now
+	}
+
+	rs := []Record{}
+	for _, t := range e.plan(in, saved) {
+		if done[t.UID] {
+			continue
+		}
+
+		r := Record{Transfer: t, Time: now(), DryRun: e.DryRun}
+
+		var err error
+		if !e.DryRun {
+			var tr *starling.TransferResult
+			if in.Account == "" {
+				tr, _, err = e.Client.TransferToSavingsGoalWithUID(ctx, t.GoalUID, t.UID, t.Amount)
+			} else {
+				tr, _, err = e.Client.TransferToAccountSavingsGoalWithUID(ctx, in.Account, t.GoalUID, t.UID, t.Amount)
+			}
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.Balance = tr.Balance
+			}
+		}
+
+		rs = append(rs, r)
+		if e.Log != nil {
+			if lerr := e.Log.Append(r); lerr != nil {
+				return rs, lerr
+			}
+		}
+
+		if err != nil {
+			return rs, errors.Wrap(err, "unable to transfer to savings goal "+t.GoalUID)
+		}
+	}
+	return rs, nil
+}
+
+// RunFeed retrieves the feed items for the account category since the given time, along
+// with the current account balances, and runs the rules against them. Transfers are made
+// into the goals held within the account.
+func (e *Engine) RunFeed(ctx context.Context, act, cat string, since time.Time) ([]Record, error) {
+	items, _, err := e.Client.Feed(ctx, act, cat, &starling.FeedOpts{Since: since})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve feed")
+	}
+
+	bal, _, err := e.Client.AccountBalances(ctx, act)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve balance")
+	}
+
+	now := time.Now
+	if e.now != nil {
+		now = e.now
+	}
+
+	return e.Run(ctx, Input{Account: act, Items: items, Since: since, Balance: bal, Now: now()})
+}
@@ -0,0 +1,278 @@
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+// goalServer is a fake API that records the transfers made into savings goals. Transfers
+// with a UID that has already been seen are not recorded again.
+type goalServer struct {
+	mu        sync.Mutex
+	transfers map[string]int64
+	order     []string
+	fail      bool
+	items     []starling.Item
+	accounts  map[string]bool // Accounts named in the transfer paths
+}
+
+func newGoalServer(t *testing.T) (*starling.Client, *goalServer, func()) {
+	gs := &goalServer{transfers: map[string]int64{}, items: feedItems, accounts: map[string]bool{}}
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	goals := func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"totalSaved": {"currency": "GBP", "minorUnits": 10000}}`)
+			return
+		}
+
+		if !strings.Contains(r.URL.Path, "/add-money/") {
+			t.Error("should add money to the savings goal", cross, r.URL.Path)
+		}
+
+		var body struct {
+			Amount starling.Amount `json:"amount"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		uid := path.Base(r.URL.Path)
+
+		gs.mu.Lock()
+		defer gs.mu.Unlock()
+
+		if gs.fail {
+			fmt.Fprintf(w, `{"transferUid": "%s", "success": false, "errors": [{"message": "INSUFFICIENT_FUNDS"}]}`, uid)
+			return
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/api/v2/account/") {
+			gs.accounts[strings.Split(r.URL.Path, "/")[4]] = true
+		}
+
+		if _, ok := gs.transfers[uid]; !ok {
+			gs.transfers[uid] = body.Amount.MinorUnits
+			gs.order = append(gs.order, uid)
+		}
+		fmt.Fprintf(w, `{"transferUid": "%s", "success": true, "errors": []}`, uid)
+	}
+	mux.HandleFunc("/api/v1/savings-goals/", goals)
+	mux.HandleFunc("/api/v2/account/act/savings-goals/", goals)
+
+	mux.HandleFunc("/api/v2/feed/account/act/category/cat", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("changesSince") == "" {
+			t.Error("should request feed items since the last run", cross)
+		}
+
+		gs.mu.Lock()
+		defer gs.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"feedItems": gs.items})
+	})
+
+	mux.HandleFunc("/api/v2/accounts/act/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"effectiveBalance": {"currency": "GBP", "minorUnits": 60000}}`)
+	})
+
+	u, _ := url.Parse(server.URL + "/")
+	client := starling.NewClientWithOptions(nil, starling.ClientOptions{BaseURL: u})
+	return client, gs, server.Close
+}
+
+func testRules() []Rule {
+	return []Rule{
+		RoundUp{GoalUID: "holiday"},
+		PercentOfIncome{GoalUID: "rainy-day", Percent: 10},
+		Sweep{GoalUID: "rainy-day", Threshold: starling.Amount{Currency: "GBP", MinorUnits: 10000}},
+	}
+}
+
+func TestEnginePlan(t *testing.T) {
+	e := &Engine{Rules: testRules()}
+	bal := &starling.AccountBalances{Effective: starling.Amount{Currency: "GBP", MinorUnits: 60000}}
+	in := Input{Items: feedItems, Balance: bal, Now: time.Date(2019, 3, 1, 23, 0, 0, 0, time.UTC)}
+
+	ts := e.Plan(in)
+	if len(ts) != 3 {
+		t.Fatal("should plan a transfer for each rule", cross, ts)
+	}
+
+	if got, want := ts[2].Amount.MinorUnits, int64(60000-75-25199-10000); got != want {
+		t.Errorf("should sweep the balance remaining after earlier transfers %s %d", cross, got)
+	}
+
+	if bal.Effective.MinorUnits != 60000 {
+		t.Error("should not modify the balance in the input", cross, bal.Effective)
+	}
+
+	e.Batch = true
+	ts = e.Plan(in)
+	if len(ts) != 2 {
+		t.Fatal("should plan one transfer per goal when batching", cross, ts)
+	}
+
+	if ts[1].GoalUID != "rainy-day" || ts[1].Amount.MinorUnits != 60000-75-10000 || ts[1].Rule != "percent-of-income+sweep" {
+		t.Error("should combine the transfers into the same goal", cross, ts[1])
+	}
+
+	// A feed item arriving before the period is planned again must not change the UID.
+	in.Items = append(in.Items, item("snack", "OUT", "MASTER_CARD", 120))
+	if again := e.Plan(in); again[1].UID != ts[1].UID || again[0].UID != ts[0].UID {
+		t.Error("should derive batched UIDs from the rules and period only", cross, again)
+	}
+}
+
+func TestEngineRunFeed(t *testing.T) {
+	client, gs, teardown := newGoalServer(t)
+	defer teardown()
+
+	log := &MemoryLog{}
+	e := &Engine{Client: client, Rules: testRules(), Log: log, Batch: true}
+	e.now = func() time.Time { return time.Date(2019, 3, 1, 23, 0, 0, 0, time.UTC) }
+
+	rs, err := e.RunFeed(context.Background(), "act", "cat", time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal("should run without error", cross, err)
+	}
+
+	if len(rs) != 2 || len(gs.order) != 2 {
+		t.Fatal("should make one transfer per goal", cross, rs)
+	}
+
+	if !gs.accounts["act"] || len(gs.accounts) != 1 {
+		t.Error("should transfer into the goals held within the account", cross, gs.accounts)
+	}
+
+	for _, r := range rs {
+		if !r.Moved() || r.Balance == nil {
+			t.Error("should record the transfer and resulting balance", cross, r)
+		}
+
+		if gs.transfers[r.UID] != r.Amount.MinorUnits {
+			t.Error("should transfer the planned amount using the planned UID", cross, r)
+		}
+	}
+
+	rs, err = e.RunFeed(context.Background(), "act", "cat", time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal("should run again without error", cross, err)
+	}
+
+	if len(rs) != 0 || len(gs.order) != 2 {
+		t.Error("should not repeat transfers recorded in the log", cross, rs)
+	}
+
+	logged, _ := log.Records()
+	if len(logged) != 2 {
+		t.Error("should log each transfer once", cross, len(logged))
+	}
+}
+
+func TestEngineDryRun(t *testing.T) {
+	client, gs, teardown := newGoalServer(t)
+	defer teardown()
+
+	log := &MemoryLog{}
+	e := &Engine{Client: client, Rules: testRules(), Log: log, DryRun: true}
+
+	rs, err := e.Run(context.Background(), Input{Items: feedItems})
+	if err != nil {
+		t.Fatal("should run without error", cross, err)
+	}
+
+	if len(rs) != 2 || len(gs.order) != 0 {
+		t.Error("should plan transfers without making them", cross, rs)
+	}
+
+	logged, _ := log.Records()
+	for _, r := range logged {
+		if !r.DryRun || r.Moved() {
+			t.Error("should log the transfers as a dry run", cross, r)
+		}
+	}
+
+	e.DryRun = false
+	rs, _ = e.Run(context.Background(), Input{Items: feedItems})
+	if len(rs) != 2 || len(gs.order) != 2 {
+		t.Error("should make transfers previously planned in a dry run", cross, rs)
+	}
+}
+
+func TestEngineRunFailure(t *testing.T) {
+	client, gs, teardown := newGoalServer(t)
+	defer teardown()
+	gs.fail = true
+
+	log := &MemoryLog{}
+	e := &Engine{Client: client, Rules: testRules(), Log: log}
+
+	rs, err := e.Run(context.Background(), Input{Items: feedItems})
+	if err == nil {
+		t.Fatal("should return an error when a transfer fails", cross)
+	}
+
+	if len(rs) != 1 || rs[0].Error != "INSUFFICIENT_FUNDS" {
+		t.Error("should stop at the failed transfer and record the reason", cross, rs)
+	}
+
+	gs.fail = false
+	rs, err = e.Run(context.Background(), Input{Items: feedItems})
+	if err != nil || len(rs) != 2 {
+		t.Error("should retry the failed transfer on the next run", cross, err, rs)
+	}
+}
+
+func TestEngineRunFeedOverlapping(t *testing.T) {
+	client, gs, teardown := newGoalServer(t)
+	defer teardown()
+
+	pending := item("lunch", "OUT", "MASTER_CARD", 500)
+	pending.Status = "PENDING"
+	gs.items = []starling.Item{item("coffee", "OUT", "MASTER_CARD", 235), pending, item("train", "OUT", "MASTER_CARD", 1290)}
+
+	log := &MemoryLog{}
+	rules := []Rule{RoundUp{GoalUID: "holiday"}, PercentOfIncome{GoalUID: "rainy-day", Percent: 10}}
+	e := &Engine{Client: client, Rules: rules, Log: log, Batch: true}
+	e.now = func() time.Time { return time.Date(2019, 3, 1, 23, 0, 0, 0, time.UTC) }
+
+	if _, err := e.RunFeed(context.Background(), "act", "cat", time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal("should run without error", cross, err)
+	}
+
+	// The second window overlaps the first, and the pending payment has since settled.
+	gs.items = []starling.Item{
+		item("lunch", "OUT", "MASTER_CARD", 500),
+		item("train", "OUT", "MASTER_CARD", 1290),
+		item("snack", "OUT", "MASTER_CARD", 120),
+		item("salary", "IN", "FASTER_PAYMENTS_IN", 250000),
+	}
+
+	rs, err := e.RunFeed(context.Background(), "act", "cat", time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal("should run again without error", cross, err)
+	}
+
+	if len(rs) != 2 || rs[0].Amount.MinorUnits != 80 || rs[1].Amount.MinorUnits != 25000 {
+		t.Error("should only save from feed items not seen before", cross, rs)
+	}
+
+	total := int64(0)
+	for _, v := range gs.transfers {
+		total += v
+	}
+
+	if want := int64(65 + 10 + 80 + 25000); total != want {
+		t.Errorf("should not save from the same feed item twice %s %d", cross, total)
+	}
+}
@@ -0,0 +1,95 @@
+package automation
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/billglover/starling"
+	"github.com/pkg/errors"
+)
+
+// Record describes what happened to a planned transfer
+type Record struct {
+	Transfer
+	Time    time.Time
+	DryRun  bool             // The transfer was planned but not made
+	Error   string           // Reason the transfer failed, empty on success
+	Balance *starling.Amount // Total saved in the goal after the transfer, if known
+}
+
+// Moved reports whether the record describes money that was moved.
+func (r Record) Moved() bool {
+	return !r.DryRun && r.Error == ""
+}
+
+// Log persists the records of transfers between runs
+type Log interface {
+	Append(r Record) error
+	Records() ([]Record, error)
+}
+
+// FileLog is a Log that appends records to the named file, one JSON object per line. The
+// file is only readable by the current user.
+type FileLog string
+
+// Append writes the record to the end of the file.
+func (l FileLog) Append(r Record) error {
+	f, err := os.OpenFile(string(l), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrap(err, "unable to open log")
+	}
+
+	if err := json.NewEncoder(f).Encode(r); err != nil {
+		f.Close()
+		return errors.Wrap(err, "unable to write log")
+	}
+	return f.Close()
+}
+
+// Records returns the records in the file, oldest first. No records are returned if the
+// file does not exist.
+func (l FileLog) Records() ([]Record, error) {
+	f, err := os.Open(string(l))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open log")
+	}
+	defer f.Close()
+
+	var rs []Record
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var r Record
+		if err := json.Unmarshal(s.Bytes(), &r); err != nil {
+			return nil, errors.Wrap(err, "unable to read log")
+		}
+		rs = append(rs, r)
+	}
+	return rs, errors.Wrap(s.Err(), "unable to read log")
+}
+
+// MemoryLog is a Log that holds records in memory. It is useful for dry runs and tests.
+type MemoryLog struct {
+	mu sync.Mutex
+	rs []Record
+}
+
+// Append adds the record to the log.
+func (l *MemoryLog) Append(r Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rs = append(l.rs, r)
+	return nil
+}
+
+// Records returns the records in the log, oldest first.
+func (l *MemoryLog) Records() ([]Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Record(nil), l.rs...), nil
+}
@@ -0,0 +1,59 @@
+package automation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+func TestFileLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "automation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	log := FileLog(filepath.Join(dir, "transfers.log"))
+
+	rs, err := log.Records()
+	if err != nil || len(rs) != 0 {
+		t.Error("should return no records before any are written", cross, err, rs)
+	}
+
+	want := []Record{
+		{
+			Transfer: newTransfer("round-up", "g", "2019-03-01", starling.Amount{Currency: "GBP", MinorUnits: 75}, []string{"coffee", "train"}),
+			Time:     time.Date(2019, 3, 1, 23, 0, 0, 0, time.UTC),
+			Balance:  &starling.Amount{Currency: "GBP", MinorUnits: 1075},
+		},
+		{
+			Transfer: newTransfer("sweep", "g", "2019-03-01", starling.Amount{Currency: "GBP", MinorUnits: 15000}, []string{"2019-03-01"}),
+			Time:     time.Date(2019, 3, 1, 23, 0, 0, 0, time.UTC),
+			Error:    "INSUFFICIENT_FUNDS",
+		},
+	}
+
+	for _, r := range want {
+		if err := log.Append(r); err != nil {
+			t.Fatal("should append the record", cross, err)
+		}
+	}
+
+	got, err := log.Records()
+	if err != nil {
+		t.Fatal("should read the records", cross, err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Error("should return the records in the order they were written", cross, got)
+	}
+
+	if fi, err := os.Stat(string(log)); err != nil || fi.Mode().Perm() != 0600 {
+		t.Error("should only be readable by the current user", cross, err)
+	}
+}
@@ -0,0 +1,206 @@
+package automation
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/billglover/starling"
+	"github.com/google/uuid"
+)
+
+// namespace is used to derive transfer UIDs so that planning the same transfer twice
+// results in the same UID.
+var namespace = uuid.MustParse("5b0e6a55-2a1c-4d8e-9f6b-3c7d2e1a0f94")
+
+// internalTransfer is the source of feed items that move money between an account and
+// its savings goals
+const internalTransfer = "INTERNAL_TRANSFER"
+
+// Input holds the data from which rules plan transfers
+type Input struct {
+	Account string                    // Account holding the goals, the default account if empty
+	Items   []starling.Item           // New feed items since the last run
+	Since   time.Time                 // Start of the feed window the items were retrieved for
+	Balance *starling.AccountBalances // Current balances, required by Sweep
+	Now     time.Time
+}
+
+// period returns the period for which transfers are planned from the input: the start of
+// the feed window if known, otherwise the day of Now.
+func (in Input) period() string {
+	if !in.Since.IsZero() {
+		return in.Since.UTC().Format(time.RFC3339)
+	}
+	return in.Now.Format("2006-01-02")
+}
+
+// Transfer is a planned transfer into a savings goal. The UID is derived from the rule,
+// the goal, the currency and the period of the transfer, but not its sources, so that
+// planning the transfer again for the same period, for example after a failed run in
+// which further feed items have arrived, results in the same UID.
+type Transfer struct {
+	UID      string
+	Rule     string
+	GoalUID  string
+	Period   string // Start of the feed window or, for a sweep, the day swept
+	Amount   starling.Amount
+	Sources  []string   // Feed item UIDs or, for a sweep, the day swept
+	Combined []Transfer `json:",omitempty"` // Transfers combined into this one when batching
+}
+
+// parts returns the transfers planned by individual rules that make up t.
+func (t Transfer) parts() []Transfer {
+	if len(t.Combined) > 0 {
+		return t.Combined
+	}
+	return []Transfer{t}
+}
+
+// newTransfer returns a transfer with a UID derived from its rule, goal, period and
+// currency.
+func newTransfer(rule, goal, period string, amt starling.Amount, sources []string) Transfer {
+	sort.Strings(sources)
+	key := strings.Join([]string{rule, goal, amt.Currency, period}, "/")
+	return Transfer{
+		UID:     uuid.NewSHA1(namespace, []byte(key)).String(),
+		Rule:    rule,
+		GoalUID: goal,
+		Period:  period,
+		Amount:  amt,
+		Sources: sources,
+	}
+}
+
+// Rule plans the transfers to be made into savings goals. Rules are evaluated in order and
+// the balance seen by each rule is reduced by the transfers planned before it.
+type Rule interface {
+	Plan(in Input) []Transfer
+}
+
+// counted reports whether a feed item has moved money in the given direction, excluding
+// transfers to and from savings goals.
+func counted(itm starling.Item, direction string) bool {
+	return itm.Direction == direction && itm.Settled() && itm.Source != internalTransfer
+}
+
+// RoundUp saves the difference between each outgoing payment and the next multiple of
+// Nearest, in minor units, multiplied by Multiplier. Payments that are already a multiple
+// of Nearest are not rounded up. The round-ups from a single run are combined into one
+// transfer per currency.
+type RoundUp struct {
+	GoalUID    string
+	Nearest    int64 // Defaults to 100, i.e. the nearest pound
+	Multiplier int64 // Defaults to 1
+}
+
+// Plan returns the round-up transfers for the outgoing payments in the input.
+func (r RoundUp) Plan(in Input) []Transfer {
+	nearest := r.Nearest
+	if nearest <= 0 {
+		nearest = 100
+	}
+
+	multiplier := r.Multiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	return combine("round-up", r.GoalUID, in, func(itm starling.Item) int64 {
+		if !counted(itm, "OUT") {
+			return 0
+		}
+
+		rem := abs(itm.Amount.MinorUnits) % nearest
+		if rem == 0 {
+			return 0
+		}
+		return (nearest - rem) * multiplier
+	})
+}
+
+// PercentOfIncome saves a percentage of each incoming payment, rounded down to the nearest
+// minor unit. If Match is set, only incoming payments for which it returns true are
+// counted. The savings from a single run are combined into one transfer per currency.
+type PercentOfIncome struct {
+	GoalUID string
+	Percent float64
+	Match   func(starling.Item) bool
+}
+
+// Plan returns the transfers for the incoming payments in the input.
+func (r PercentOfIncome) Plan(in Input) []Transfer {
+	return combine("percent-of-income", r.GoalUID, in, func(itm starling.Item) int64 {
+		if !counted(itm, "IN") || (r.Match != nil && !r.Match(itm)) {
+			return 0
+		}
+		return int64(float64(abs(itm.Amount.MinorUnits)) * r.Percent / 100)
+	})
+}
+
+// combine sums the amounts saved from each item in the input into one transfer per
+// currency. Currencies are returned in alphabetical order.
+func combine(rule, goal string, in Input, save func(starling.Item) int64) []Transfer {
+	totals := map[string]int64{}
+	sources := map[string][]string{}
+	for _, itm := range in.Items {
+		v := save(itm)
+		if v <= 0 {
+			continue
+		}
+		totals[itm.Amount.Currency] += v
+		sources[itm.Amount.Currency] = append(sources[itm.Amount.Currency], itm.FeedItemUID)
+	}
+
+	currencies := make([]string, 0, len(totals))
+	for cur := range totals {
+		currencies = append(currencies, cur)
+	}
+	sort.Strings(currencies)
+
+	ts := []Transfer{}
+	for _, cur := range currencies {
+		amt := starling.Amount{Currency: cur, MinorUnits: totals[cur]}
+		ts = append(ts, newTransfer(rule, goal, in.period(), amt, sources[cur]))
+	}
+	return ts
+}
+
+// Sweep moves anything in the effective balance above Threshold into a savings goal once
+// a day. No transfer is planned before At, the time after midnight at which the day is
+// considered to have ended, so that a sweep made late in the day is not repeated with a
+// different amount. Only one sweep is made per day as the transfer UID is derived from the
+// date.
+type Sweep struct {
+	GoalUID   string
+	Threshold starling.Amount
+	At        time.Duration
+}
+
+// Plan returns the sweep transfer for the day of the input, if any.
+func (r Sweep) Plan(in Input) []Transfer {
+	if in.Balance == nil {
+		return nil
+	}
+
+	day := time.Date(in.Now.Year(), in.Now.Month(), in.Now.Day(), 0, 0, 0, 0, in.Now.Location())
+	if in.Now.Sub(day) < r.At {
+		return nil
+	}
+
+	bal := in.Balance.Effective
+	if bal.Currency != r.Threshold.Currency || bal.MinorUnits <= r.Threshold.MinorUnits {
+		return nil
+	}
+
+	amt := starling.Amount{Currency: bal.Currency, MinorUnits: bal.MinorUnits - r.Threshold.MinorUnits}
+	d := day.Format("2006-01-02")
+	return []Transfer{newTransfer("sweep", r.GoalUID, d, amt, []string{d})}
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
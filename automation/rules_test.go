@@ -0,0 +1,172 @@
+package automation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+const (
+	tick  = "\u2713"
+	cross = "\u2717"
+)
+
+func item(uid, direction, source string, minor int64) starling.Item {
+	return starling.Item{
+		FeedItemUID: uid,
+		Direction:   direction,
+		Source:      source,
+		Status:      "SETTLED",
+		Amount:      starling.Amount{Currency: "GBP", MinorUnits: minor},
+	}
+}
+
+var feedItems = []starling.Item{
+	item("coffee", "OUT", "MASTER_CARD", 235),
+	item("lunch", "OUT", "MASTER_CARD", 500),
+	item("train", "OUT", "MASTER_CARD", 1290),
+	item("salary", "IN", "FASTER_PAYMENTS_IN", 250000),
+	item("refund", "IN", "MASTER_CARD", 1999),
+	item("goal", "OUT", "INTERNAL_TRANSFER", 1001),
+	func() starling.Item {
+		itm := item("declined", "OUT", "MASTER_CARD", 199)
+		itm.Status = "DECLINED"
+		return itm
+	}(),
+}
+
+var roundUpTC = []struct {
+	name string
+	rule RoundUp
+	want int64
+}{
+	{
+		name: "nearest pound",
+		rule: RoundUp{GoalUID: "g"},
+		want: 65 + 10,
+	},
+	{
+		name: "nearest pound with multiplier",
+		rule: RoundUp{GoalUID: "g", Multiplier: 3},
+		want: (65 + 10) * 3,
+	},
+	{
+		name: "nearest five pounds",
+		rule: RoundUp{GoalUID: "g", Nearest: 500},
+		want: 265 + 210,
+	},
+}
+
+func TestRoundUp(t *testing.T) {
+	for _, tc := range roundUpTC {
+		t.Run(tc.name, func(st *testing.T) {
+			ts := tc.rule.Plan(Input{Items: feedItems})
+			if len(ts) != 1 {
+				st.Fatal("should plan a single transfer", cross, ts)
+			}
+
+			if got := ts[0].Amount; got != (starling.Amount{Currency: "GBP", MinorUnits: tc.want}) {
+				st.Error("should round up outgoing payments", cross, got)
+			}
+
+			if ts[0].GoalUID != "g" || ts[0].Rule != "round-up" {
+				st.Error("should plan a transfer into the goal", cross, ts[0])
+			}
+		})
+	}
+}
+
+func TestRoundUpNothingToSave(t *testing.T) {
+	ts := RoundUp{GoalUID: "g"}.Plan(Input{Items: []starling.Item{item("lunch", "OUT", "MASTER_CARD", 500)}})
+	if len(ts) != 0 {
+		t.Error("should not plan a transfer when there is nothing to round up", cross, ts)
+	}
+}
+
+func TestPercentOfIncome(t *testing.T) {
+	ts := PercentOfIncome{GoalUID: "g", Percent: 10}.Plan(Input{Items: feedItems})
+	if len(ts) != 1 {
+		t.Fatal("should plan a single transfer", cross, ts)
+	}
+
+	if got, want := ts[0].Amount.MinorUnits, int64(25000+199); got != want {
+		t.Errorf("should save a percentage of each incoming payment %s %d", cross, got)
+	}
+
+	salary := func(itm starling.Item) bool { return itm.Source == "FASTER_PAYMENTS_IN" }
+	ts = PercentOfIncome{GoalUID: "g", Percent: 10, Match: salary}.Plan(Input{Items: feedItems})
+	if len(ts) != 1 || ts[0].Amount.MinorUnits != 25000 {
+		t.Error("should only count incoming payments that match", cross, ts)
+	}
+}
+
+var sweepTC = []struct {
+	name    string
+	now     time.Time
+	balance int64
+	want    int64
+}{
+	{
+		name:    "end of day above threshold",
+		now:     time.Date(2019, 3, 1, 23, 30, 0, 0, time.UTC),
+		balance: 65000,
+		want:    15000,
+	},
+	{
+		name:    "before end of day",
+		now:     time.Date(2019, 3, 1, 22, 59, 0, 0, time.UTC),
+		balance: 65000,
+	},
+	{
+		name:    "below threshold",
+		now:     time.Date(2019, 3, 1, 23, 30, 0, 0, time.UTC),
+		balance: 45000,
+	},
+}
+
+func TestSweep(t *testing.T) {
+	rule := Sweep{GoalUID: "g", Threshold: starling.Amount{Currency: "GBP", MinorUnits: 50000}, At: 23 * time.Hour}
+
+	for _, tc := range sweepTC {
+		t.Run(tc.name, func(st *testing.T) {
+			bal := &starling.AccountBalances{Effective: starling.Amount{Currency: "GBP", MinorUnits: tc.balance}}
+			ts := rule.Plan(Input{Balance: bal, Now: tc.now})
+
+			if tc.want == 0 {
+				if len(ts) != 0 {
+					st.Error("should not plan a sweep", cross, ts)
+				}
+				return
+			}
+
+			if len(ts) != 1 || ts[0].Amount.MinorUnits != tc.want {
+				st.Fatal("should sweep the balance above the threshold", cross, ts)
+			}
+
+			if ts[0].Sources[0] != "2019-03-01" {
+				st.Error("should identify the day swept", cross, ts[0].Sources)
+			}
+		})
+	}
+}
+
+func TestTransferUIDs(t *testing.T) {
+	since := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	a := RoundUp{GoalUID: "g"}.Plan(Input{Items: feedItems, Since: since})
+	b := RoundUp{GoalUID: "g"}.Plan(Input{Items: feedItems[:1], Since: since})
+	c := RoundUp{GoalUID: "other"}.Plan(Input{Items: feedItems, Since: since})
+	d := RoundUp{GoalUID: "g"}.Plan(Input{Items: feedItems, Since: since.Add(time.Hour)})
+
+	if a[0].UID != b[0].UID {
+		t.Error("should derive the same UID for the same period whatever the feed items", cross)
+	}
+
+	if a[0].UID == c[0].UID {
+		t.Error("should derive a different UID for a different goal", cross)
+	}
+
+	if a[0].UID == d[0].UID {
+		t.Error("should derive a different UID for a different period", cross)
+	}
+}
@@ -0,0 +1,87 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AccountBalances represents the balances on an individual account as exact amounts
+type AccountBalances struct {
+	Cleared        Amount `json:"clearedBalance"`
+	Effective      Amount `json:"effectiveBalance"`
+	PendingTxns    Amount `json:"pendingTransactions"`
+	Available      Amount `json:"availableToSpend"`
+	Overdraft      Amount `json:"acceptedOverdraft"`
+	Amount         Amount `json:"amount"`
+	TotalCleared   Amount `json:"totalClearedBalance"`
+	TotalEffective Amount `json:"totalEffectiveBalance"`
+}
+
+// AccountBalances returns the balances for an individual account.
+//
+// Note: AccountBalances uses the v2 API which is still under active development.
+func (c *Client) AccountBalances(ctx context.Context, act string) (*AccountBalances, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/accounts/"+act+"/balance", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var b *AccountBalances
+	resp, err := c.Do(ctx, req, &b)
+	return b, resp, err
+}
+
+// DailyBalance is the balance on an account at the end of a day
+type DailyBalance struct {
+	Date    time.Time
+	Balance Amount
+}
+
+// BalanceHistory reconstructs the effective balance at the end of each day between from
+// and to inclusive, oldest first. The history is calculated by walking the feed for the
+// account category backwards from the current effective balance. Days are in UTC. An error
+// is returned if the balance or the feed cannot be retrieved.
+func (c *Client) BalanceHistory(ctx context.Context, act, cat string, from, to time.Time) ([]DailyBalance, *http.Response, error) {
+	b, resp, err := c.AccountBalances(ctx, act)
+	if err != nil {
+		return nil, resp, errors.Wrap(err, "unable to retrieve balance")
+	}
+	if b == nil {
+		return nil, resp, errors.New("unable to retrieve balance: no balance returned")
+	}
+
+	start := midnight(from)
+	end := midnight(to)
+
+	items, resp, err := c.Feed(ctx, act, cat, &FeedOpts{Since: start})
+	if err != nil {
+		return nil, resp, errors.Wrap(err, "unable to retrieve feed")
+	}
+
+	cur := b.Effective
+	days := []DailyBalance{}
+	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+		next := d.AddDate(0, 0, 1)
+
+		// Undo every transaction made after the end of the day.
+		bal := cur.MinorUnits
+		for _, itm := range items {
+			if !itm.Settled() || itm.Amount.Currency != cur.Currency || itm.TransactionTime.Before(next) {
+				continue
+			}
+
+			if itm.Direction == "IN" {
+				bal -= itm.Amount.MinorUnits
+			} else {
+				bal += itm.Amount.MinorUnits
+			}
+		}
+
+		days = append(days, DailyBalance{Date: d, Balance: Amount{Currency: cur.Currency, MinorUnits: bal}})
+	}
+
+	return days, resp, nil
+}
@@ -0,0 +1,106 @@
+package starling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const balancesMock = `{
+	"clearedBalance": {"currency": "GBP", "minorUnits": 9500},
+	"effectiveBalance": {"currency": "GBP", "minorUnits": 10000},
+	"pendingTransactions": {"currency": "GBP", "minorUnits": 500},
+	"availableToSpend": {"currency": "GBP", "minorUnits": 60000},
+	"acceptedOverdraft": {"currency": "GBP", "minorUnits": 50000},
+	"amount": {"currency": "GBP", "minorUnits": 10000},
+	"totalClearedBalance": {"currency": "GBP", "minorUnits": 19500},
+	"totalEffectiveBalance": {"currency": "GBP", "minorUnits": 20000}
+}`
+
+func TestAccountBalances(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/accounts/24492cc9-77dd-4155-87a2-ec2580daf139/balance", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, balancesMock)
+	})
+
+	got, _, err := client.AccountBalances(context.Background(), "24492cc9-77dd-4155-87a2-ec2580daf139")
+	checkNoError(t, err)
+
+	if got.Effective != (Amount{Currency: "GBP", MinorUnits: 10000}) {
+		t.Error("should return the effective balance", cross, got.Effective)
+	}
+
+	if got.Available.MinorUnits != 60000 || got.Overdraft.MinorUnits != 50000 || got.TotalEffective.MinorUnits != 20000 {
+		t.Error("should return the balances matching the mock response", cross, got)
+	}
+}
+
+func TestAccountBalancesForbidden(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/accounts/24492cc9-77dd-4155-87a2-ec2580daf139/balance", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	got, resp, err := client.AccountBalances(context.Background(), "24492cc9-77dd-4155-87a2-ec2580daf139")
+	checkHasError(t, err)
+	checkStatus(t, resp, http.StatusForbidden)
+
+	if got != nil {
+		t.Error("should not return a balance", cross)
+	}
+}
+
+func TestBalanceHistory(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act, cat := "24492cc9-77dd-4155-87a2-ec2580daf139", "8d8c0f3b-f685-49ed-835e-db2ff8cef703"
+
+	mux.HandleFunc("/api/v2/accounts/"+act+"/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, balancesMock)
+	})
+
+	mux.HandleFunc("/api/v2/feed/account/"+act+"/category/"+cat, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("changesSince"); got != "2019-01-01T00:00:00Z" {
+			t.Error("should request the feed from the start of the first day", cross, got)
+		}
+
+		fmt.Fprint(w, `{"feedItems": [
+			{"amount": {"currency": "GBP", "minorUnits": 300}, "direction": "OUT", "status": "PENDING", "transactionTime": "2019-01-04T09:00:00Z"},
+			{"amount": {"currency": "GBP", "minorUnits": 500}, "direction": "OUT", "status": "SETTLED", "transactionTime": "2019-01-03T12:00:00Z"},
+			{"amount": {"currency": "GBP", "minorUnits": 2000}, "direction": "IN", "status": "SETTLED", "transactionTime": "2019-01-02T08:00:00Z"},
+			{"amount": {"currency": "GBP", "minorUnits": 700}, "direction": "OUT", "status": "DECLINED", "transactionTime": "2019-01-02T10:00:00Z"},
+			{"amount": {"currency": "GBP", "minorUnits": 100}, "direction": "OUT", "status": "SETTLED", "transactionTime": "2019-01-01T18:00:00Z"}
+		]}`)
+	})
+
+	got, _, err := client.BalanceHistory(context.Background(), act, cat, time.Date(2019, 1, 1, 15, 0, 0, 0, time.UTC), time.Date(2019, 1, 3, 0, 0, 0, 0, time.UTC))
+	checkNoError(t, err)
+
+	want := []int64{
+		10000 + 300 + 500 - 2000, // end of 1 January
+		10000 + 300 + 500,        // end of 2 January
+		10000 + 300,              // end of 3 January
+	}
+
+	if len(got) != len(want) {
+		t.Fatal("should return a balance for each day", cross, len(got))
+	}
+
+	for i, d := range got {
+		if !d.Date.Equal(time.Date(2019, 1, 1+i, 0, 0, 0, 0, time.UTC)) {
+			t.Error("should return the days in order", cross, d.Date)
+		}
+
+		if d.Balance != (Amount{Currency: "GBP", MinorUnits: want[i]}) {
+			t.Error("should reconstruct the balance at the end of the day", cross, d.Date, d.Balance)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package starling
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 )
 
@@ -33,3 +34,156 @@ func (c *Client) Card(ctx context.Context) (*Card, *http.Response, error) {
 
 	return card, resp, nil
 }
+
+// CardChannel is a channel through which a card can be used
+type CardChannel string
+
+// Channels through which a card can be used
+const (
+	ChannelATM          CardChannel = "atm"
+	ChannelOnline       CardChannel = "online"
+	ChannelMobileWallet CardChannel = "mobile-wallet"
+	ChannelGambling     CardChannel = "gambling"
+	ChannelPOS          CardChannel = "pos"
+	ChannelMagstripe    CardChannel = "mag-stripe"
+)
+
+// CardSummary represents a card and the channels through which it can be used
+type CardSummary struct {
+	UID                 string `json:"cardUid"`
+	PublicToken         string `json:"publicToken"`
+	AssociationUID      string `json:"cardAssociationUid"`
+	EndOfCardNumber     string `json:"endOfCardNumber"`
+	Enabled             bool   `json:"enabled"`
+	Cancelled           bool   `json:"cancelled"`
+	ActivationRequested bool   `json:"activationRequested"`
+	Activated           bool   `json:"activated"`
+	ATMEnabled          bool   `json:"atmEnabled"`
+	OnlineEnabled       bool   `json:"onlineEnabled"`
+	MobileWalletEnabled bool   `json:"mobileWalletEnabled"`
+	GamblingEnabled     bool   `json:"gamblingEnabled"`
+	POSEnabled          bool   `json:"posEnabled"`
+	MagstripeEnabled    bool   `json:"magStripeEnabled"`
+}
+
+// Locked reports whether the card has been locked.
+func (c CardSummary) Locked() bool {
+	return !c.Enabled
+}
+
+// ChannelEnabled reports whether the card can be used through the channel. A locked card
+// cannot be used through any channel.
+func (c CardSummary) ChannelEnabled(ch CardChannel) bool {
+	if !c.Enabled {
+		return false
+	}
+
+	switch ch {
+	case ChannelATM:
+		return c.ATMEnabled
+	case ChannelOnline:
+		return c.OnlineEnabled
+	case ChannelMobileWallet:
+		return c.MobileWalletEnabled
+	case ChannelGambling:
+		return c.GamblingEnabled
+	case ChannelPOS:
+		return c.POSEnabled
+	case ChannelMagstripe:
+		return c.MagstripeEnabled
+	}
+	return false
+}
+
+type cards struct {
+	Cards []CardSummary `json:"cards"`
+}
+
+// Cards returns the cards held by the account holder.
+//
+// Note: Cards uses the v2 API which is still under active development.
+func (c *Client) Cards(ctx context.Context) ([]CardSummary, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/cards", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cs cards
+	resp, err := c.Do(ctx, req, &cs)
+	if err != nil {
+		return nil, resp, err
+	}
+	return cs.Cards, resp, nil
+}
+
+// LockCard prevents the card from being used through any channel. The card is returned
+// as it stands once the change has been made, or nil if it cannot be retrieved.
+//
+// Note: LockCard uses the v2 API which is still under active development.
+func (c *Client) LockCard(ctx context.Context, uid string) (*CardSummary, *http.Response, error) {
+	return c.setCardControl(ctx, uid, "enabled", false)
+}
+
+// UnlockCard allows the card to be used through the channels that are enabled. The card is
+// returned as it stands once the change has been made, or nil if it cannot be retrieved.
+//
+// Note: UnlockCard uses the v2 API which is still under active development.
+func (c *Client) UnlockCard(ctx context.Context, uid string) (*CardSummary, *http.Response, error) {
+	return c.setCardControl(ctx, uid, "enabled", true)
+}
+
+// SetCardChannel enables or disables use of the card through a single channel. The card is
+// returned as it stands once the change has been made, or nil if it cannot be retrieved. An
+// error is returned if the channel is not supported.
+//
+// Note: SetCardChannel uses the v2 API which is still under active development.
+func (c *Client) SetCardChannel(ctx context.Context, uid string, ch CardChannel, enabled bool) (*CardSummary, *http.Response, error) {
+	switch ch {
+	case ChannelATM, ChannelOnline, ChannelMobileWallet, ChannelGambling, ChannelPOS, ChannelMagstripe:
+	default:
+		return nil, nil, fmt.Errorf("unsupported card channel %q", ch)
+	}
+	return c.setCardControl(ctx, uid, string(ch)+"-enabled", enabled)
+}
+
+type cardControl struct {
+	Enabled bool `json:"enabled"`
+}
+
+type cardControlResponse struct {
+	Success bool          `json:"success"`
+	Errors  []ErrorDetail `json:"errors"`
+}
+
+// setCardControl changes a card control. The API responds without a payload on success,
+// so an error is only returned from a payload if it reports that the change failed. The
+// cards are then retrieved to return the updated card, which is left nil if it cannot be
+// found as the change itself has already been made.
+func (c *Client) setCardControl(ctx context.Context, uid, control string, enabled bool) (*CardSummary, *http.Response, error) {
+	req, err := c.NewRequest("PUT", "/api/v2/cards/"+uid+"/controls/"+control, cardControl{Enabled: enabled})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ccResp *cardControlResponse
+	resp, err := c.Do(ctx, req, &ccResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if ccResp != nil && !ccResp.Success {
+		return nil, resp, detailErrors(ccResp.Errors)
+	}
+
+	cs, _, err := c.Cards(ctx)
+	if err != nil {
+		return nil, resp, nil
+	}
+
+	for i := range cs {
+		if cs[i].UID == uid {
+			return &cs[i], resp, nil
+		}
+	}
+	return nil, resp, nil
+}
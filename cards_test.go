@@ -97,3 +97,156 @@ func TestCardForbidden(t *testing.T) {
 		t.Error("should not return a card")
 	}
 }
+
+const cardsMock = `{
+	"cards": [
+		{
+			"cardUid": "a3b5c7d9-0000-4000-8000-000000000001",
+			"endOfCardNumber": "1234",
+			"enabled": true,
+			"activated": true,
+			"atmEnabled": true,
+			"onlineEnabled": true,
+			"mobileWalletEnabled": false,
+			"gamblingEnabled": false,
+			"posEnabled": true,
+			"magStripeEnabled": false
+		},
+		{
+			"cardUid": "a3b5c7d9-0000-4000-8000-000000000002",
+			"endOfCardNumber": "5678",
+			"enabled": false,
+			"atmEnabled": true
+		}
+	]
+}`
+
+func TestCards(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/cards", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, cardsMock)
+	})
+
+	got, _, err := client.Cards(context.Background())
+	checkNoError(t, err)
+
+	if len(got) != 2 {
+		t.Fatal("should return all cards", cross, len(got))
+	}
+
+	if got[0].Locked() || !got[1].Locked() {
+		t.Error("should report whether a card is locked", cross)
+	}
+
+	if !got[0].ChannelEnabled(ChannelOnline) || got[0].ChannelEnabled(ChannelGambling) {
+		t.Error("should report whether a channel is enabled", cross)
+	}
+
+	if got[1].ChannelEnabled(ChannelATM) {
+		t.Error("should not enable any channel on a locked card", cross)
+	}
+}
+
+// cardUID identifies the first card in cardsMock
+const cardUID = "a3b5c7d9-0000-4000-8000-000000000001"
+
+var cardControlTC = []struct {
+	name    string
+	path    string
+	enabled bool
+	call    func(c *Client) (*CardSummary, *http.Response, error)
+}{
+	{
+		name: "lock",
+		path: "/controls/enabled",
+		call: func(c *Client) (*CardSummary, *http.Response, error) {
+			return c.LockCard(context.Background(), cardUID)
+		},
+	},
+	{
+		name:    "unlock",
+		path:    "/controls/enabled",
+		enabled: true,
+		call: func(c *Client) (*CardSummary, *http.Response, error) {
+			return c.UnlockCard(context.Background(), cardUID)
+		},
+	},
+	{
+		name: "disable gambling",
+		path: "/controls/gambling-enabled",
+		call: func(c *Client) (*CardSummary, *http.Response, error) {
+			return c.SetCardChannel(context.Background(), cardUID, ChannelGambling, false)
+		},
+	},
+	{
+		name:    "enable magstripe",
+		path:    "/controls/mag-stripe-enabled",
+		enabled: true,
+		call: func(c *Client) (*CardSummary, *http.Response, error) {
+			return c.SetCardChannel(context.Background(), cardUID, ChannelMagstripe, true)
+		},
+	},
+}
+
+func TestCardControls(t *testing.T) {
+	for _, tc := range cardControlTC {
+		t.Run(tc.name, func(st *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/api/v2/cards", func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(st, r, http.MethodGet)
+				fmt.Fprint(w, cardsMock)
+			})
+
+			mux.HandleFunc("/api/v2/cards/"+cardUID+tc.path, func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(st, r, http.MethodPut)
+
+				var ctl cardControl
+				if err := json.NewDecoder(r.Body).Decode(&ctl); err != nil {
+					st.Fatal("should send a card control", cross, err)
+				}
+
+				if ctl.Enabled != tc.enabled {
+					st.Error("should send the requested control state", cross, ctl.Enabled)
+				}
+			})
+
+			card, resp, err := tc.call(client)
+			checkNoError(st, err)
+			checkStatus(st, resp, http.StatusOK)
+
+			if card == nil || card.UID != cardUID {
+				st.Error("should return the updated card", cross, card)
+			}
+		})
+	}
+}
+
+func TestCardControlsUnsuccessful(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/cards/"+cardUID+"/controls/enabled", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodPut)
+		fmt.Fprint(w, `{"success": false, "errors": [{"message": "CARD_CANCELLED"}]}`)
+	})
+
+	_, _, err := client.LockCard(context.Background(), cardUID)
+	checkHasError(t, err)
+
+	if errs, ok := err.(Errors); !ok || len(errs) != 1 || errs[0] != "CARD_CANCELLED" {
+		t.Error("should return the errors reported by the API", cross, err)
+	}
+}
+
+func TestSetCardChannelUnsupported(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	_, _, err := client.SetCardChannel(context.Background(), cardUID, CardChannel("contactless"), false)
+	checkHasError(t, err)
+}
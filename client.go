@@ -10,6 +10,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -30,6 +32,32 @@ const (
 // ClientOptions is a set of options that can be specified when creating a Starling client
 type ClientOptions struct {
 	BaseURL *url.URL
+
+	// CheckScopes causes the client to check that the token has been granted the scopes
+	// required by each request before it is sent. The scopes granted to the token are
+	// retrieved on the first request and cached.
+	CheckScopes bool
+
+	// Refresher is used to refresh the token when it is within ExpiryWindow of expiring.
+	// A request rejected as unauthorised is retried once after the token is refreshed.
+	Refresher TokenRefresher
+
+	// OnExpiry is called with the token expiry time when the token is first found to be
+	// within ExpiryWindow of expiring, before it is refreshed.
+	OnExpiry func(expiresAt time.Time)
+
+	// ExpiryWindow is how long before expiry a token is refreshed. It defaults to five
+	// minutes.
+	ExpiryWindow time.Duration
+
+	// AccountValidator, if set, is used to check UK sort codes and account numbers before
+	// contact and payee accounts are created or updated. See the validation package.
+	AccountValidator AccountValidator
+}
+
+// AccountValidator checks that an account number is valid for a sort code
+type AccountValidator interface {
+	Check(sortCode, accountNumber string) error
 }
 
 // Client holds configuration items for the Starling client and provides methods
@@ -39,6 +67,21 @@ type Client struct {
 
 	userAgent string
 	client    *http.Client
+
+	checkScopes bool
+	scopesMu    sync.Mutex
+	scopes      []string
+
+	refresher    TokenRefresher
+	onExpiry     func(time.Time)
+	expiryWindow time.Duration
+	refreshMu    sync.Mutex
+	tokenMu      sync.Mutex
+	expiresAt    time.Time
+	notified     bool
+	refreshes    int
+
+	validator AccountValidator
 }
 
 // NewClient returns a new Starling API client. If a nil httpClient is
@@ -60,6 +103,11 @@ func NewClient(cc *http.Client) *Client {
 func NewClientWithOptions(cc *http.Client, opts ClientOptions) *Client {
 	c := NewClient(cc)
 	c.baseURL = opts.BaseURL
+	c.checkScopes = opts.CheckScopes
+	c.refresher = opts.Refresher
+	c.onExpiry = opts.OnExpiry
+	c.expiryWindow = opts.ExpiryWindow
+	c.validator = opts.AccountValidator
 	return c
 }
 
@@ -108,9 +156,38 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 // is decoded and stored in the value pointed to by v.
 // Inspiration: https://github.com/google/go-github/blob/master/github/github.go
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
+	if c.checkScopes {
+		if err := c.authorize(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	gen := 0
+	if c.tracksExpiry() {
+		var err error
+		if gen, err = c.checkExpiry(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	if r, ok := c.route(req); ok {
+		ctx = context.WithValue(ctx, opKey{}, r.op)
+	}
+
 	req = req.WithContext(ctx)
 	resp, err := c.client.Do(req)
 
+	// Retry an unauthorised request once if the token can be refreshed.
+	if err == nil && resp.StatusCode == http.StatusUnauthorized && c.refresher != nil {
+		if r, ok := retry(req); ok {
+			resp.Body.Close()
+			if err := c.refresh(ctx, gen); err != nil {
+				return nil, err
+			}
+			resp, err = c.client.Do(r)
+		}
+	}
+
 	if err != nil {
 		select {
 		case <-ctx.Done():
@@ -14,7 +14,7 @@ type Contact struct {
 
 // Contacts are a list of payees
 type contacts struct {
-	Contacts []Contact
+	Contacts []Contact `json:"contacts"`
 }
 
 // HALContacts is a HAL wrapper around the Contacts type.
@@ -32,12 +32,19 @@ type ContactAccount struct {
 	SortCode      string `json:"sortCode"`
 }
 
+// Validate checks the sort code and account number using the validator.
+func (ca ContactAccount) Validate(v AccountValidator) error {
+	return v.Check(ca.SortCode, ca.AccountNumber)
+}
+
 // ContactAccounts holds a list of accounts for a payee
 type contactAccounts struct {
 	ContactAccounts []ContactAccount `json:"contactAccounts"`
 }
 
 // Contacts returns the contacts for the current customer.
+//
+// Deprecated: use Payees instead.
 func (c *Client) Contacts(ctx context.Context) ([]Contact, *http.Response, error) {
 	req, err := c.NewRequest("GET", "/api/v1/contacts", nil)
 	if err != nil {
@@ -59,6 +66,8 @@ func (c *Client) Contacts(ctx context.Context) ([]Contact, *http.Response, error
 }
 
 // Contact returns an individual contact for the current customer.
+//
+// Deprecated: use Payees instead.
 func (c *Client) Contact(ctx context.Context, uid string) (*Contact, *http.Response, error) {
 	req, err := c.NewRequest("GET", "/api/v1/contacts/"+uid, nil)
 	if err != nil {
@@ -72,6 +81,8 @@ func (c *Client) Contact(ctx context.Context, uid string) (*Contact, *http.Respo
 
 // DeleteContact deletes an individual contact for the current customer. It returns http.StatusNoContent
 // on success. No payload is returned.
+//
+// Deprecated: use DeletePayee instead.
 func (c *Client) DeleteContact(ctx context.Context, uid string) (*http.Response, error) {
 	req, err := c.NewRequest("DELETE", "/api/v1/contacts/"+uid, nil)
 	if err != nil {
@@ -83,6 +94,8 @@ func (c *Client) DeleteContact(ctx context.Context, uid string) (*http.Response,
 }
 
 // ContactAccounts returns the accounts for a given contact.
+//
+// Deprecated: use Payees instead.
 func (c *Client) ContactAccounts(ctx context.Context, uid string) ([]ContactAccount, *http.Response, error) {
 	req, err := c.NewRequest("GET", "/api/v1/contacts/"+uid+"/accounts", nil)
 	if err != nil {
@@ -95,6 +108,8 @@ func (c *Client) ContactAccounts(ctx context.Context, uid string) ([]ContactAcco
 }
 
 // ContactAccount returns the specified account for a given contact.
+//
+// Deprecated: use Payees instead.
 func (c *Client) ContactAccount(ctx context.Context, cUID, aUID string) (*ContactAccount, *http.Response, error) {
 	req, err := c.NewRequest("GET", "/api/v1/contacts/"+cUID+"/accounts/"+aUID, nil)
 	if err != nil {
@@ -106,8 +121,18 @@ func (c *Client) ContactAccount(ctx context.Context, cUID, aUID string) (*Contac
 	return ca, resp, nil
 }
 
-// CreateContactAccount creates the specified account for a given contact.
+// CreateContactAccount creates the specified account for a given contact. An error is
+// returned without calling the API if the client has an AccountValidator and the account
+// fails validation.
+//
+// Deprecated: use CreatePayee or CreatePayeeAccount instead.
 func (c *Client) CreateContactAccount(ctx context.Context, ca ContactAccount) (string, *http.Response, error) {
+	if c.validator != nil {
+		if err := ca.Validate(c.validator); err != nil {
+			return "", nil, err
+		}
+	}
+
 	req, err := c.NewRequest("POST", "/api/v1/contacts", ca)
 	if err != nil {
 		return "", nil, err
@@ -3,6 +3,7 @@ package starling
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -523,3 +524,37 @@ func testPostContactAccount(t *testing.T, name string, ca ContactAccount, respBo
 
 	checkStatus(t, resp, respStatus)
 }
+
+// validatorFunc is an AccountValidator backed by a function
+type validatorFunc func(sortCode, accountNumber string) error
+
+func (f validatorFunc) Check(sortCode, accountNumber string) error {
+	return f(sortCode, accountNumber)
+}
+
+var errInvalidAccount = errors.New("invalid account")
+
+// rejectAccount is an AccountValidator that rejects account number 00000000
+var rejectAccount = validatorFunc(func(sortCode, accountNumber string) error {
+	if accountNumber == "00000000" {
+		return errInvalidAccount
+	}
+	return nil
+})
+
+// TestCreateContactAccountInvalid confirms that an account that fails validation is not
+// sent to the API.
+func TestCreateContactAccountInvalid(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.validator = rejectAccount
+
+	mux.HandleFunc("/api/v1/contacts", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not send an invalid account to the API", cross)
+	})
+
+	ca := ContactAccount{Name: "Dave Bowman", AccountNumber: "00000000", SortCode: "040004"}
+	if _, _, err := client.CreateContactAccount(context.Background(), ca); err != errInvalidAccount {
+		t.Error("should return the validation error", cross, err)
+	}
+}
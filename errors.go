@@ -1,5 +1,10 @@
 package starling
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Error specifies additional methods on the standard error interface
 type Error interface {
 	error
@@ -13,3 +18,17 @@ func (e AuthError) Error() string { return string(e) }
 
 // Temporary indicates if an error is temporary
 func (e AuthError) Temporary() bool { return false }
+
+// ScopeError indicates that the authentication token has not been granted the scopes
+// required by an operation
+type ScopeError struct {
+	Operation string
+	Missing   []string
+}
+
+func (e ScopeError) Error() string {
+	return fmt.Sprintf("%s requires scopes not granted to the token: %s", e.Operation, strings.Join(e.Missing, ", "))
+}
+
+// Temporary indicates if an error is temporary
+func (e ScopeError) Temporary() bool { return false }
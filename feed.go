@@ -13,21 +13,37 @@ type feed struct {
 
 // Item is a single customer transaction in their feed
 type Item struct {
-	FeedItemUID              string    `json:"feedItemUid"`
-	CategoryUID              string    `json:"categoryUid"`
-	Amount                   Amount    `json:"amount"`
-	SourceAmount             Amount    `json:"sourceAmount"`
-	Direction                string    `json:"direction"`
-	TransactionTime          time.Time `json:"transactionTime"`
-	Source                   string    `json:"source"`
-	SourceSubType            string    `json:"sourceSubType"`
-	Status                   string    `json:"status"`
-	CounterPartyType         string    `json:"counterPartyType"`
-	CounterPartyUID          string    `json:"counterPartyUid"`
-	CounterPartySubEntityUID string    `json:"counterPartySubEntityUid"`
-	Reference                string    `json:"reference"`
-	Country                  string    `json:"country"`
-	SpendingCategory         string    `json:"spendingCategory"`
+	FeedItemUID              string       `json:"feedItemUid"`
+	CategoryUID              string       `json:"categoryUid"`
+	Amount                   Amount       `json:"amount"`
+	SourceAmount             Amount       `json:"sourceAmount"`
+	Direction                string       `json:"direction"`
+	TransactionTime          time.Time    `json:"transactionTime"`
+	Source                   string       `json:"source"`
+	SourceSubType            string       `json:"sourceSubType"`
+	Status                   string       `json:"status"`
+	CounterPartyType         string       `json:"counterPartyType"`
+	CounterPartyUID          string       `json:"counterPartyUid"`
+	CounterPartySubEntityUID string       `json:"counterPartySubEntityUid"`
+	Reference                string       `json:"reference"`
+	Country                  string       `json:"country"`
+	SpendingCategory         string       `json:"spendingCategory"`
+	RoundUp                  *ItemRoundUp `json:"roundUp,omitempty"`
+}
+
+// unsettledStatuses lists the statuses of feed items that have not moved, or will not
+// move, any money
+var unsettledStatuses = map[string]bool{
+	"UPCOMING":      true,
+	"DECLINED":      true,
+	"REVERSED":      true,
+	"ACCOUNT_CHECK": true,
+}
+
+// Settled reports whether the feed item has moved money. Upcoming, declined and reversed
+// items, along with account checks, have not.
+func (i Item) Settled() bool {
+	return !unsettledStatuses[i.Status]
 }
 
 // FeedOpts defines options that can be passed when requesting a feed
@@ -335,3 +335,17 @@ func TestFeedItemForbidden(t *testing.T) {
 		t.Error("should not return an item")
 	}
 }
+
+func TestItemSettled(t *testing.T) {
+	for _, s := range []string{"UPCOMING", "DECLINED", "REVERSED", "ACCOUNT_CHECK"} {
+		if (Item{Status: s}).Settled() {
+			t.Error("should not treat the item as settled", cross, s)
+		}
+	}
+
+	for _, s := range []string{"SETTLED", "PENDING"} {
+		if !(Item{Status: s}).Settled() {
+			t.Error("should treat the item as settled", cross, s)
+		}
+	}
+}
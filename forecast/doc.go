@@ -0,0 +1,23 @@
+/*
+Package forecast projects the balance of a Starling account forward in time.
+
+The known recurring payments on an account, such as standing orders, direct debits and
+recurring transfers into savings goals, are expanded into a day-by-day projection of the
+balance:
+
+	in, err := forecast.Gather(ctx, client, time.Now())
+	f, err := forecast.Project(*in, time.Now(), 90)
+
+	for _, w := range f.Warnings {
+		fmt.Println(w.Date, w.Kind, w.Balance.MinorUnits)
+	}
+
+The progress of a savings goal can be projected month by month from its recurring
+transfer and the ad-hoc top-ups and withdrawals in its history:
+
+	in, err := forecast.GatherGoal(ctx, client, act, goalUID, time.Now(), 6)
+	p, err := forecast.ProjectGoal(*in, time.Now(), &forecast.GoalOpts{By: holiday})
+
+	fmt.Println(p.Completion, p.Required.MinorUnits)
+*/
+package forecast
@@ -0,0 +1,240 @@
+package forecast
+
+import (
+	"sort"
+	"time"
+
+	"github.com/billglover/starling"
+	"github.com/billglover/starling/insights"
+	"github.com/pkg/errors"
+)
+
+const dateLayout = "2006-01-02"
+
+// Source identifies the origin of a projected flow
+type Source string
+
+// Sources of projected flows
+const (
+	ScheduledPayment Source = "SCHEDULED_PAYMENT"
+	DirectDebit      Source = "DIRECT_DEBIT"
+	SavingsTransfer  Source = "SAVINGS_GOAL_TRANSFER"
+	Other            Source = "OTHER"
+)
+
+// Flow is a single projected movement of money into or out of an account. Outflows have
+// a negative amount.
+type Flow struct {
+	Date        time.Time
+	Amount      starling.Amount
+	Source      Source
+	UID         string // UID of the payment order, mandate or savings goal
+	Description string
+}
+
+// Inputs holds the account data from which a forecast is projected
+type Inputs struct {
+	Balance          starling.Balance
+	PaymentOrders    []starling.PaymentOrder
+	Mandates         []starling.DirectDebitMandate
+	DDTransactions   []starling.DDTransaction                     // Historical direct debits used to predict future amounts
+	SavingsTransfers map[string]starling.RecurringTransferRequest // Recurring savings goal transfers keyed by savings goal UID
+	Flows            []Flow                                       // Additional flows, such as expected income
+}
+
+// WarningKind describes the threshold crossed by a projected balance
+type WarningKind string
+
+// Thresholds for which warnings are raised
+const (
+	BelowZero      WarningKind = "BELOW_ZERO"
+	BelowOverdraft WarningKind = "BELOW_OVERDRAFT"
+)
+
+// Warning records the first day of a period in which the projected balance is below a
+// threshold
+type Warning struct {
+	Date    time.Time
+	Kind    WarningKind
+	Balance starling.Amount
+}
+
+// Day is the projected balance at the end of a single day
+type Day struct {
+	Date    time.Time
+	Flows   []Flow
+	Balance starling.Amount
+}
+
+// Forecast is a day-by-day projection of an account balance
+type Forecast struct {
+	Opening   starling.Amount
+	Overdraft starling.Amount
+	Days      []Day
+	Warnings  []Warning
+}
+
+// Lowest returns the day with the lowest projected balance. The earliest day is returned
+// if more than one day shares the lowest balance.
+func (f Forecast) Lowest() Day {
+	var low Day
+	for i, d := range f.Days {
+		if i == 0 || d.Balance.MinorUnits < low.Balance.MinorUnits {
+			low = d
+		}
+	}
+	return low
+}
+
+// Project expands the recurring payments in the inputs into a projection of the balance
+// for the given number of days after from. Payments due on from itself are assumed to be
+// reflected in the effective balance already. Only flows in the currency of the balance
+// are included. An error is returned if days is negative or a recurrence rule cannot be
+// expanded.
+func Project(in Inputs, from time.Time, days int) (*Forecast, error) {
+	if days < 0 {
+		return nil, errors.Errorf("unable to project a negative number of days: %d", days)
+	}
+
+	cur := in.Balance.Currency
+	start := date(from).AddDate(0, 0, 1)
+	end := date(from).AddDate(0, 0, days)
+
+	flows, err := expand(in, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Forecast{
+		Opening:   signed(in.Balance.Effective, cur),
+		Overdraft: starling.Amount{Currency: cur, MinorUnits: starling.MinorUnits(in.Balance.Overdraft, cur)},
+		Days:      make([]Day, days),
+		Warnings:  []Warning{},
+	}
+
+	byDay := map[time.Time][]Flow{}
+	for _, fl := range flows {
+		if fl.Amount.Currency != cur {
+			continue
+		}
+		d := date(fl.Date)
+		byDay[d] = append(byDay[d], fl)
+	}
+
+	bal := f.Opening.MinorUnits
+	belowZero, belowOverdraft := false, false
+	for i := range f.Days {
+		d := start.AddDate(0, 0, i)
+		dayFlows := byDay[d]
+		sort.SliceStable(dayFlows, func(i, j int) bool { return dayFlows[i].Amount.MinorUnits > dayFlows[j].Amount.MinorUnits })
+
+		for _, fl := range dayFlows {
+			bal += fl.Amount.MinorUnits
+		}
+		f.Days[i] = Day{Date: d, Flows: dayFlows, Balance: starling.Amount{Currency: cur, MinorUnits: bal}}
+
+		if bal < 0 && !belowZero {
+			f.Warnings = append(f.Warnings, Warning{Date: d, Kind: BelowZero, Balance: f.Days[i].Balance})
+		}
+		if bal < -f.Overdraft.MinorUnits && !belowOverdraft {
+			f.Warnings = append(f.Warnings, Warning{Date: d, Kind: BelowOverdraft, Balance: f.Days[i].Balance})
+		}
+		belowZero, belowOverdraft = bal < 0, bal < -f.Overdraft.MinorUnits
+	}
+
+	return f, nil
+}
+
+// expand returns every flow in the inputs that falls between start and end inclusive.
+func expand(in Inputs, start, end time.Time) ([]Flow, error) {
+	flows := []Flow{}
+
+	for _, po := range in.PaymentOrders {
+		if po.CancelledAt != "" || po.Immediate {
+			continue
+		}
+
+		amt := signed(-po.Amount, po.Currency)
+		dates := []time.Time{}
+		switch {
+		case po.RecurrenceRule.Frequency != "":
+			ds, err := po.RecurrenceRule.Between(start, end)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to expand payment order "+po.UID)
+			}
+			dates = ds
+		case po.NextDate != "":
+			d, err := time.Parse(dateLayout, po.NextDate)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to parse next date for payment order "+po.UID)
+			}
+			if !d.Before(start) && !d.After(end) {
+				dates = append(dates, d)
+			}
+		}
+
+		for _, d := range dates {
+			flows = append(flows, Flow{Date: d, Amount: amt, Source: ScheduledPayment, UID: po.UID, Description: po.Reference})
+		}
+	}
+
+	ddTxns, err := insights.FromDirectDebits(in.DDTransactions)
+	if err != nil {
+		return nil, err
+	}
+	series := insights.DetectRecurring(ddTxns, start, &insights.RecurringOpts{MinOccurrences: 2})
+	for _, m := range in.Mandates {
+		if m.Cancelled != "" || m.Status == "CANCELLED" {
+			continue
+		}
+		for _, s := range series {
+			if s.CounterParty != m.UID {
+				continue
+			}
+			// Projected dates keep the time of day of past payments, so a payment due on
+			// the last day falls after end itself.
+			next := end.AddDate(0, 0, 1)
+			for _, d := range s.Projected(next) {
+				if d.Before(start) || !d.Before(next) {
+					continue
+				}
+				amt := starling.Amount{Currency: s.NextAmount.Currency, MinorUnits: -s.NextAmount.MinorUnits}
+				flows = append(flows, Flow{Date: d, Amount: amt, Source: DirectDebit, UID: m.UID, Description: m.OriginatorName})
+			}
+		}
+	}
+
+	for uid, rt := range in.SavingsTransfers {
+		dates, err := rt.RecurrenceRule.Between(start, end)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to expand recurring transfer for savings goal "+uid)
+		}
+		amt := starling.Amount{Currency: rt.Currency, MinorUnits: -rt.MinorUnits}
+		for _, d := range dates {
+			flows = append(flows, Flow{Date: d, Amount: amt, Source: SavingsTransfer, UID: uid})
+		}
+	}
+
+	for _, fl := range in.Flows {
+		if d := date(fl.Date); !d.Before(start) && !d.After(end) {
+			flows = append(flows, fl)
+		}
+	}
+
+	return flows, nil
+}
+
+// signed converts a decimal amount into a signed number of minor units.
+func signed(v float64, currency string) starling.Amount {
+	a := starling.Amount{Currency: currency, MinorUnits: starling.MinorUnits(v, currency)}
+	if v < 0 {
+		a.MinorUnits = -a.MinorUnits
+	}
+	return a
+}
+
+// date truncates t to midnight UTC on the same calendar day.
+func date(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
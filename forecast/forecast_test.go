@@ -0,0 +1,167 @@
+package forecast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+const (
+	tick  = "\u2713"
+	cross = "\u2717"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+var forecastInputs = Inputs{
+	Balance: starling.Balance{Effective: 100.00, Overdraft: 50.00, Currency: "GBP"},
+	PaymentOrders: []starling.PaymentOrder{
+		{
+			UID:            "1e22a383-0dd6-4845-a5fd-17c55920381d",
+			Currency:       "GBP",
+			Amount:         60.00,
+			Reference:      "Rent",
+			RecurrenceRule: starling.RecurrenceRule{StartDate: "2019-01-05", Frequency: "MONTHLY"},
+		},
+		{
+			UID:         "f8e714f1-f5a3-4bd8-a6f5-28e44a6b1416",
+			Currency:    "GBP",
+			Amount:      10.24,
+			Reference:   "Dinner",
+			Immediate:   true,
+			NextDate:    "2019-02-02",
+			PaymentType: "STANDING_ORDER",
+		},
+		{
+			UID:            "0c1e4cc1-ab9f-4c5f-9c5c-8cfaec1e9d9d",
+			Currency:       "GBP",
+			Amount:         500.00,
+			Reference:      "Cancelled",
+			RecurrenceRule: starling.RecurrenceRule{StartDate: "2019-01-05", Frequency: "MONTHLY"},
+			CancelledAt:    "2019-01-06T10:00:00.000Z",
+		},
+	},
+	Mandates: []starling.DirectDebitMandate{
+		{UID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d", Status: "LIVE", OriginatorName: "Energy Co"},
+	},
+	DDTransactions: []starling.DDTransaction{
+		{UID: "1", Currency: "GBP", Amount: -42.13, Direction: "OUTBOUND", Created: "2018-12-10T23:30:00.000Z", MandateUID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d"},
+		{UID: "2", Currency: "GBP", Amount: -42.13, Direction: "OUTBOUND", Created: "2019-01-10T23:30:00.000Z", MandateUID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d"},
+	},
+	SavingsTransfers: map[string]starling.RecurringTransferRequest{
+		"e43d3060-2c83-4bb9-ac8c-c627b9c45f8b": {
+			RecurrenceRule: starling.RecurrenceRule{StartDate: "2019-01-07", Frequency: "WEEKLY"},
+			Amount:         starling.Amount{Currency: "GBP", MinorUnits: 1000},
+		},
+	},
+	Flows: []Flow{
+		{Date: day(2019, 2, 15), Amount: starling.Amount{Currency: "GBP", MinorUnits: 20000}, Source: Other, Description: "Salary"},
+	},
+}
+
+func TestProject(t *testing.T) {
+	f, err := Project(forecastInputs, day(2019, 1, 31), 28)
+	if err != nil {
+		t.Fatal("should project without error", cross, err)
+	}
+
+	if len(f.Days) != 28 || !f.Days[0].Date.Equal(day(2019, 2, 1)) {
+		t.Fatal("should project each day after the start date", cross, len(f.Days))
+	}
+
+	if f.Opening.MinorUnits != 10000 || f.Overdraft.MinorUnits != 5000 {
+		t.Error("should start from the effective balance", cross, f.Opening, f.Overdraft)
+	}
+
+	balances := map[time.Time]int64{}
+	for _, d := range f.Days {
+		balances[d.Date] = d.Balance.MinorUnits
+	}
+
+	tcs := []struct {
+		date time.Time
+		want int64
+	}{
+		{date: day(2019, 2, 4), want: 10000 - 1000},
+		{date: day(2019, 2, 5), want: 10000 - 1000 - 6000},
+		{date: day(2019, 2, 10), want: 10000 - 1000 - 6000 - 4213},
+		{date: day(2019, 2, 11), want: 10000 - 2000 - 6000 - 4213},
+		{date: day(2019, 2, 18), want: 10000 - 3000 - 6000 - 4213 + 20000},
+	}
+
+	for _, tc := range tcs {
+		if got := balances[tc.date]; got != tc.want {
+			t.Errorf("should project the balance on %s %s %d", tc.date.Format(dateLayout), cross, got)
+		}
+	}
+
+	if len(f.Warnings) != 1 || f.Warnings[0].Kind != BelowZero || !f.Warnings[0].Date.Equal(day(2019, 2, 10)) {
+		t.Error("should warn when the balance drops below zero", cross, f.Warnings)
+	}
+
+	if low := f.Lowest(); !low.Date.Equal(day(2019, 2, 11)) {
+		t.Error("should return the day with the lowest balance", cross, low.Date)
+	}
+}
+
+func TestProjectLastDay(t *testing.T) {
+	f, err := Project(forecastInputs, day(2019, 1, 31), 10)
+	if err != nil {
+		t.Fatal("should project without error", cross, err)
+	}
+
+	last := f.Days[len(f.Days)-1]
+	if !last.Date.Equal(day(2019, 2, 10)) || len(last.Flows) != 1 || last.Flows[0].Source != DirectDebit {
+		t.Error("should include a direct debit due on the last day", cross, last)
+	}
+}
+
+func TestProjectNegativeDays(t *testing.T) {
+	if _, err := Project(forecastInputs, day(2019, 1, 31), -1); err == nil {
+		t.Error("should return an error for a negative number of days", cross)
+	}
+}
+
+func TestProjectOverdraft(t *testing.T) {
+	in := Inputs{
+		Balance: starling.Balance{Effective: 10.00, Overdraft: 20.00, Currency: "GBP"},
+		Flows: []Flow{
+			{Date: day(2019, 1, 2), Amount: starling.Amount{Currency: "GBP", MinorUnits: -2000}},
+			{Date: day(2019, 1, 3), Amount: starling.Amount{Currency: "GBP", MinorUnits: -2000}},
+			{Date: day(2019, 1, 4), Amount: starling.Amount{Currency: "EUR", MinorUnits: -9000}},
+		},
+	}
+
+	f, err := Project(in, day(2019, 1, 1), 5)
+	if err != nil {
+		t.Fatal("should project without error", cross, err)
+	}
+
+	if len(f.Warnings) != 2 {
+		t.Fatal("should warn once for each threshold crossed", cross, f.Warnings)
+	}
+
+	if f.Warnings[1].Kind != BelowOverdraft || f.Warnings[1].Balance.MinorUnits != -3000 {
+		t.Error("should warn when the balance drops below the overdraft", cross, f.Warnings[1])
+	}
+
+	if got := f.Days[4].Balance.MinorUnits; got != -3000 {
+		t.Error("should ignore flows in other currencies", cross, got)
+	}
+}
+
+func TestProjectInvalidRule(t *testing.T) {
+	in := Inputs{
+		Balance: starling.Balance{Currency: "GBP"},
+		PaymentOrders: []starling.PaymentOrder{
+			{UID: "1", Currency: "GBP", RecurrenceRule: starling.RecurrenceRule{StartDate: "2019-01-01", Frequency: "SOMETIMES"}},
+		},
+	}
+
+	if _, err := Project(in, day(2019, 1, 1), 5); err == nil {
+		t.Error("should return an error for an invalid recurrence rule", cross)
+	}
+}
@@ -0,0 +1,62 @@
+package forecast
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/billglover/starling"
+	"github.com/pkg/errors"
+)
+
+// Gather retrieves the inputs for a forecast from the Starling API. Direct debits taken
+// in the year before now are used to predict future direct debit amounts. Savings goals
+// without a recurring transfer are skipped. An error is returned if any of the required
+// data cannot be retrieved.
+func Gather(ctx context.Context, c *starling.Client, now time.Time) (*Inputs, error) {
+	in := &Inputs{SavingsTransfers: map[string]starling.RecurringTransferRequest{}}
+
+	bal, _, err := c.AccountBalance(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve account balance")
+	}
+	if bal != nil {
+		in.Balance = *bal
+	}
+
+	in.PaymentOrders, _, err = c.ScheduledPayments(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve scheduled payments")
+	}
+
+	in.Mandates, _, err = c.DirectDebitMandates(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve direct debit mandates")
+	}
+
+	dr := &starling.DateRange{From: now.AddDate(-1, 0, 0), To: now}
+	in.DDTransactions, _, err = c.DDTransactions(ctx, dr)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve direct debit transactions")
+	}
+
+	goals, _, err := c.SavingsGoals(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve savings goals")
+	}
+
+	for _, g := range goals {
+		rt, resp, err := c.RecurringTransfer(ctx, g.UID)
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to retrieve recurring transfer for savings goal "+g.UID)
+		}
+		if rt != nil && rt.RecurrenceRule.Frequency != "" {
+			in.SavingsTransfers[g.UID] = *rt
+		}
+	}
+
+	return in, nil
+}
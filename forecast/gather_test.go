@@ -0,0 +1,91 @@
+package forecast
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+func TestGather(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api/v1/accounts/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"effectiveBalance": 100.00, "acceptedOverdraft": 50.00, "currency": "GBP"}`)
+	})
+
+	mux.HandleFunc("/api/v1/payments/scheduled", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_embedded": {"paymentOrders": [{"paymentOrderId": "1e22a383-0dd6-4845-a5fd-17c55920381d", "amount": 60.00, "currency": "GBP"}]}}`)
+	})
+
+	mux.HandleFunc("/api/v1/direct-debit/mandates", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_embedded": {"mandates": [{"uid": "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d", "status": "LIVE"}]}}`)
+	})
+
+	mux.HandleFunc("/api/v1/transactions/direct-debit", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("from") != "2018-02-01" {
+			t.Error("should request a year of direct debit history", cross, r.URL.Query().Get("from"))
+		}
+		fmt.Fprint(w, `{"_embedded": {"transactions": [{"id": "1", "amount": -42.13, "mandateId": "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d"}]}}`)
+	})
+
+	mux.HandleFunc("/api/v1/savings-goals", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"savingsGoalList": [{"uid": "with-transfer"}, {"uid": "without-transfer"}]}`)
+	})
+
+	mux.HandleFunc("/api/v1/savings-goals/with-transfer/recurring-transfer", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"recurrenceRule": {"startDate": "2017-09-23", "frequency": "WEEKLY"}, "currencyAndAmount": {"currency": "GBP", "minorUnits": 1000}}`)
+	})
+
+	mux.HandleFunc("/api/v1/savings-goals/without-transfer/recurring-transfer", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	u, _ := url.Parse(server.URL + "/")
+	client := starling.NewClientWithOptions(nil, starling.ClientOptions{BaseURL: u})
+
+	in, err := Gather(context.Background(), client, time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal("should gather inputs without error", cross, err)
+	}
+
+	if in.Balance.Currency != "GBP" || in.Balance.Effective != 100.00 {
+		t.Error("should include the account balance", cross, in.Balance)
+	}
+
+	if len(in.PaymentOrders) != 1 || len(in.Mandates) != 1 || len(in.DDTransactions) != 1 {
+		t.Error("should include payment orders, mandates and direct debits", cross, in)
+	}
+
+	if len(in.SavingsTransfers) != 1 {
+		t.Fatal("should skip savings goals without a recurring transfer", cross, in.SavingsTransfers)
+	}
+
+	if rt := in.SavingsTransfers["with-transfer"]; rt.MinorUnits != 1000 {
+		t.Error("should key recurring transfers by savings goal", cross, rt)
+	}
+}
+
+func TestGatherForbidden(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	u, _ := url.Parse(server.URL + "/")
+	client := starling.NewClientWithOptions(nil, starling.ClientOptions{BaseURL: u})
+
+	if _, err := Gather(context.Background(), client, time.Now()); err == nil {
+		t.Error("should return an error when unable to retrieve the balance", cross)
+	}
+}
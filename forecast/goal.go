@@ -0,0 +1,260 @@
+package forecast
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/billglover/starling"
+	"github.com/billglover/starling/insights"
+	"github.com/pkg/errors"
+)
+
+// defaultHistoryMonths is the number of months of goal history used to estimate ad-hoc
+// contributions if no other period is given
+const defaultHistoryMonths = 6
+
+// GoalInputs holds the savings goal data from which a projection is made
+type GoalInputs struct {
+	Goal          starling.SavingsGoal
+	Recurring     *starling.RecurringTransferRequest // Nil if the goal has no recurring transfer
+	Items         []starling.Item                    // Feed items for the category of the goal
+	HistoryMonths int                                // Months of feed items in Items, zero if not known
+}
+
+// GoalOpts defines options that can be passed when projecting a savings goal
+type GoalOpts struct {
+	Months        int       // Number of months to project, defaults to 12
+	By            time.Time // Date by which the target should be reached, if any
+	HistoryMonths int       // Months of history used to estimate ad-hoc contributions, defaults to the inputs or 6
+}
+
+// GoalMonth is the projected progress of a savings goal at the end of a single month
+type GoalMonth struct {
+	Month     insights.Month
+	Recurring starling.Amount // Contributions made by the recurring transfer
+	AdHoc     starling.Amount // Expected ad-hoc top-ups less withdrawals
+	Saved     starling.Amount // Total saved at the end of the month
+}
+
+// GoalProjection is a month-by-month projection of the progress of a savings goal
+type GoalProjection struct {
+	Target     starling.Amount
+	Saved      starling.Amount
+	AdHocRate  starling.Amount // Average monthly ad-hoc top-ups less withdrawals
+	Completion time.Time       // Date on which the target is reached, zero if not within the projection
+	Required   starling.Amount // Additional monthly contribution needed to reach the target by the date given
+	Months     []GoalMonth
+}
+
+// Reached reports whether the target is reached within the projection.
+func (p GoalProjection) Reached() bool {
+	return !p.Completion.IsZero()
+}
+
+// ProjectGoal projects the progress of a savings goal month by month from the given date.
+// Contributions are made by the recurring transfer on the dates given by its recurrence
+// rule. Ad-hoc top-ups and withdrawals are estimated from the feed items for the goal in
+// the months before from, excluding those made by the recurring transfer, and are assumed
+// to arrive at the end of each month, pro-rated for the first month. The history used
+// defaults to the months of feed items in the inputs. An error is returned if more history
+// is requested than the inputs hold or the recurrence rule cannot be expanded.
+func ProjectGoal(in GoalInputs, from time.Time, opts *GoalOpts) (*GoalProjection, error) {
+	o := GoalOpts{Months: 12, HistoryMonths: defaultHistoryMonths}
+	if in.HistoryMonths > 0 {
+		o.HistoryMonths = in.HistoryMonths
+	}
+	if opts != nil {
+		if opts.Months > 0 {
+			o.Months = opts.Months
+		}
+		if opts.HistoryMonths > 0 {
+			o.HistoryMonths = opts.HistoryMonths
+		}
+		o.By = opts.By
+	}
+
+	if in.HistoryMonths > 0 && o.HistoryMonths > in.HistoryMonths {
+		return nil, errors.Errorf("unable to estimate ad-hoc contributions over %d months: only %d months of history gathered", o.HistoryMonths, in.HistoryMonths)
+	}
+
+	cur := in.Goal.TotalSaved.Currency
+	start := date(from)
+
+	rate, err := adHocRate(in, start, o.HistoryMonths)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &GoalProjection{
+		Target:    in.Goal.Target,
+		Saved:     in.Goal.TotalSaved,
+		AdHocRate: starling.Amount{Currency: cur, MinorUnits: rate},
+		Required:  starling.Amount{Currency: cur},
+		Months:    make([]GoalMonth, o.Months),
+	}
+
+	target := in.Goal.Target.MinorUnits
+	saved := in.Goal.TotalSaved.MinorUnits
+	if saved >= target {
+		p.Completion = start
+	}
+
+	m := insights.MonthOf(start)
+	for i := range p.Months {
+		first := time.Date(m.Year, m.Month, 1, 0, 0, 0, 0, time.UTC)
+		last := first.AddDate(0, 1, -1)
+		if i == 0 {
+			first = start.AddDate(0, 0, 1)
+		}
+
+		gm := GoalMonth{
+			Month:     m,
+			Recurring: starling.Amount{Currency: cur},
+			AdHoc:     starling.Amount{Currency: cur},
+		}
+
+		dates, amt, err := recurring(in, first, last)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range dates {
+			saved += amt
+			gm.Recurring.MinorUnits += amt
+			if saved >= target && p.Completion.IsZero() {
+				p.Completion = d
+			}
+		}
+
+		gm.AdHoc.MinorUnits = rate
+		if i == 0 {
+			gm.AdHoc.MinorUnits = rate * int64(m.Days()-start.Day()) / int64(m.Days())
+		}
+		saved += gm.AdHoc.MinorUnits
+		if saved >= target && p.Completion.IsZero() {
+			p.Completion = last
+		}
+
+		gm.Saved = starling.Amount{Currency: cur, MinorUnits: saved}
+		p.Months[i] = gm
+		m = m.Next()
+	}
+
+	if !o.By.IsZero() {
+		req, err := required(in, start, date(o.By), rate)
+		if err != nil {
+			return nil, err
+		}
+		p.Required.MinorUnits = req
+	}
+
+	return p, nil
+}
+
+// required returns the additional monthly contribution needed to reach the target of the
+// goal by the given date, rounded up to the nearest minor unit.
+func required(in GoalInputs, start, by time.Time, rate int64) (int64, error) {
+	months := int64((by.Year()-start.Year())*12 + int(by.Month()-start.Month()))
+	if months < 1 {
+		months = 1
+	}
+
+	dates, amt, err := recurring(in, start.AddDate(0, 0, 1), by)
+	if err != nil {
+		return 0, err
+	}
+
+	remaining := in.Goal.Target.MinorUnits - in.Goal.TotalSaved.MinorUnits - int64(len(dates))*amt - rate*months
+	if remaining <= 0 {
+		return 0, nil
+	}
+	return (remaining + months - 1) / months, nil
+}
+
+// recurring returns the dates between first and last inclusive on which the recurring
+// transfer into the goal is made, along with the amount of each transfer. No dates are
+// returned if the goal has no recurring transfer or it is in a different currency.
+func recurring(in GoalInputs, first, last time.Time) ([]time.Time, int64, error) {
+	rt := in.Recurring
+	if rt == nil || rt.RecurrenceRule.Frequency == "" || rt.Currency != in.Goal.TotalSaved.Currency || first.After(last) {
+		return nil, 0, nil
+	}
+
+	dates, err := rt.RecurrenceRule.Between(first, last)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "unable to expand recurring transfer for savings goal "+in.Goal.UID)
+	}
+	return dates, rt.MinorUnits, nil
+}
+
+// adHocRate returns the average monthly top-ups less withdrawals made to the goal in the
+// given number of months before start. Incoming items that match the amount and date of
+// the recurring transfer are assumed to have been made by it and are excluded.
+func adHocRate(in GoalInputs, start time.Time, months int) (int64, error) {
+	from := start.AddDate(0, -months, 0)
+
+	scheduled := map[time.Time]bool{}
+	dates, amt, err := recurring(in, from, start)
+	if err != nil {
+		return 0, err
+	}
+	for _, d := range dates {
+		scheduled[date(d)] = true
+	}
+
+	var net int64
+	for _, itm := range in.Items {
+		d := date(itm.TransactionTime)
+		if d.Before(from) || !d.Before(start) || itm.Amount.Currency != in.Goal.TotalSaved.Currency {
+			continue
+		}
+
+		switch itm.Direction {
+		case "IN":
+			if scheduled[d] && itm.Amount.MinorUnits == amt {
+				continue
+			}
+			net += itm.Amount.MinorUnits
+		case "OUT":
+			net -= itm.Amount.MinorUnits
+		}
+	}
+	return net / int64(months), nil
+}
+
+// GatherGoal retrieves the inputs for a savings goal projection from the Starling API.
+// Feed items are retrieved for the given number of months before now, or six months if
+// months is zero, and the number of months is recorded in the inputs. The category of a
+// savings goal shares its UID. An error is returned if any of the required data cannot be
+// retrieved.
+func GatherGoal(ctx context.Context, c *starling.Client, act, goalUID string, now time.Time, months int) (*GoalInputs, error) {
+	if months <= 0 {
+		months = defaultHistoryMonths
+	}
+
+	goal, _, err := c.SavingsGoal(ctx, goalUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve savings goal "+goalUID)
+	}
+	if goal == nil {
+		return nil, errors.New("unable to retrieve savings goal " + goalUID + ": no goal returned")
+	}
+
+	in := &GoalInputs{Goal: *goal, HistoryMonths: months}
+
+	rt, resp, err := c.RecurringTransfer(ctx, goalUID)
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotFound:
+	case err != nil:
+		return nil, errors.Wrap(err, "unable to retrieve recurring transfer for savings goal "+goalUID)
+	case rt != nil && rt.RecurrenceRule.Frequency != "":
+		in.Recurring = rt
+	}
+
+	in.Items, _, err = c.Feed(ctx, act, goalUID, &starling.FeedOpts{Since: date(now).AddDate(0, -months, 0)})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve feed for savings goal "+goalUID)
+	}
+
+	return in, nil
+}
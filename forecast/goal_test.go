@@ -0,0 +1,174 @@
+package forecast
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+	"github.com/billglover/starling/insights"
+)
+
+func goalItem(d time.Time, direction string, minor int64) starling.Item {
+	return starling.Item{
+		TransactionTime: d.Add(9 * time.Hour),
+		Direction:       direction,
+		Amount:          starling.Amount{Currency: "GBP", MinorUnits: minor},
+	}
+}
+
+var goalInputs = GoalInputs{
+	Goal: starling.SavingsGoal{
+		UID:        "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b",
+		Target:     starling.Amount{Currency: "GBP", MinorUnits: 100000},
+		TotalSaved: starling.Amount{Currency: "GBP", MinorUnits: 40000},
+	},
+	Recurring: &starling.RecurringTransferRequest{
+		RecurrenceRule: starling.RecurrenceRule{StartDate: "2019-01-15", Frequency: starling.FrequencyMonthly},
+		Amount:         starling.Amount{Currency: "GBP", MinorUnits: 10000},
+	},
+	Items: []starling.Item{
+		goalItem(day(2019, time.January, 15), "IN", 10000),
+		goalItem(day(2019, time.February, 15), "IN", 10000),
+		goalItem(day(2019, time.February, 20), "IN", 9000),
+		goalItem(day(2019, time.March, 1), "OUT", 3000),
+		goalItem(day(2018, time.August, 1), "IN", 50000), // before the history used
+	},
+}
+
+func TestProjectGoal(t *testing.T) {
+	from := day(2019, time.March, 10)
+	p, err := ProjectGoal(goalInputs, from, &GoalOpts{By: day(2019, time.June, 10)})
+	if err != nil {
+		t.Fatal("should project without error", cross, err)
+	}
+
+	if got := p.AdHocRate.MinorUnits; got != 1000 {
+		t.Errorf("should estimate ad-hoc contributions excluding recurring transfers %s %d", cross, got)
+	}
+
+	if len(p.Months) != 12 {
+		t.Fatal("should project twelve months by default", cross, len(p.Months))
+	}
+
+	march := p.Months[0]
+	if march.Month != (insights.Month{Year: 2019, Month: time.March}) || march.Recurring.MinorUnits != 10000 || march.AdHoc.MinorUnits != 677 {
+		t.Error("should pro-rate ad-hoc contributions in the first month", cross, march)
+	}
+
+	if got := p.Months[1].Saved.MinorUnits; got != 61677 {
+		t.Errorf("should accumulate contributions month by month %s %d", cross, got)
+	}
+
+	if !p.Reached() || !p.Completion.Equal(day(2019, time.August, 15)) {
+		t.Error("should project the date on which the target is reached", cross, p.Completion)
+	}
+
+	if got := p.Required.MinorUnits; got != 9000 {
+		t.Errorf("should calculate the additional monthly contribution required %s %d", cross, got)
+	}
+}
+
+func TestProjectGoalWithoutContributions(t *testing.T) {
+	in := GoalInputs{Goal: goalInputs.Goal}
+
+	p, err := ProjectGoal(in, day(2019, time.March, 10), &GoalOpts{Months: 3, By: day(2019, time.December, 10)})
+	if err != nil {
+		t.Fatal("should project without error", cross, err)
+	}
+
+	if p.Reached() {
+		t.Error("should not reach the target without contributions", cross, p.Completion)
+	}
+
+	if got := p.Months[2].Saved.MinorUnits; got != 40000 {
+		t.Errorf("should project a constant balance %s %d", cross, got)
+	}
+
+	if got := p.Required.MinorUnits; got != 6667 {
+		t.Errorf("should round the required contribution up %s %d", cross, got)
+	}
+}
+
+func TestProjectGoalReached(t *testing.T) {
+	in := goalInputs
+	in.Goal.TotalSaved.MinorUnits = 120000
+
+	p, err := ProjectGoal(in, day(2019, time.March, 10), &GoalOpts{By: day(2019, time.June, 10)})
+	if err != nil {
+		t.Fatal("should project without error", cross, err)
+	}
+
+	if !p.Completion.Equal(day(2019, time.March, 10)) {
+		t.Error("should report a goal that has already been reached", cross, p.Completion)
+	}
+
+	if p.Required.MinorUnits != 0 {
+		t.Error("should not require any further contribution", cross, p.Required)
+	}
+}
+
+func TestProjectGoalHistory(t *testing.T) {
+	in := goalInputs
+	in.HistoryMonths = 3
+
+	p, err := ProjectGoal(in, day(2019, time.March, 10), nil)
+	if err != nil {
+		t.Fatal("should project without error", cross, err)
+	}
+
+	if got := p.AdHocRate.MinorUnits; got != 2000 {
+		t.Errorf("should estimate ad-hoc contributions over the history gathered %s %d", cross, got)
+	}
+
+	if _, err := ProjectGoal(in, day(2019, time.March, 10), &GoalOpts{HistoryMonths: 6}); err == nil {
+		t.Error("should return an error when more history is requested than was gathered", cross)
+	}
+}
+
+func TestGatherGoal(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	goal := "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b"
+
+	mux.HandleFunc("/api/v1/savings-goals/"+goal, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"uid": "`+goal+`", "target": {"currency": "GBP", "minorUnits": 100000}, "totalSaved": {"currency": "GBP", "minorUnits": 40000}}`)
+	})
+
+	mux.HandleFunc("/api/v1/savings-goals/"+goal+"/recurring-transfer", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	mux.HandleFunc("/api/v2/feed/account/act/category/"+goal, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("changesSince"); got != "2018-09-01T00:00:00Z" {
+			t.Error("should request six months of goal history", cross, got)
+		}
+		fmt.Fprint(w, `{"feedItems": [{"direction": "IN", "amount": {"currency": "GBP", "minorUnits": 9000}}]}`)
+	})
+
+	u, _ := url.Parse(server.URL + "/")
+	client := starling.NewClientWithOptions(nil, starling.ClientOptions{BaseURL: u})
+
+	in, err := GatherGoal(context.Background(), client, "act", goal, time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC), 0)
+	if err != nil {
+		t.Fatal("should gather inputs without error", cross, err)
+	}
+
+	if in.Goal.UID != goal || in.Goal.TotalSaved.MinorUnits != 40000 {
+		t.Error("should include the savings goal", cross, in.Goal)
+	}
+
+	if in.Recurring != nil {
+		t.Error("should not include a recurring transfer when there is none", cross, in.Recurring)
+	}
+
+	if len(in.Items) != 1 || in.HistoryMonths != 6 {
+		t.Error("should include the feed items for the goal", cross, in.Items, in.HistoryMonths)
+	}
+}
@@ -0,0 +1,98 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// FundsConfirmation reports whether an account can cover a proposed payment
+type FundsConfirmation struct {
+	Available        bool `json:"requestedAmountAvailableToSpend"`
+	WouldBeOverdrawn bool `json:"accountWouldBeInOverdraftIfRequestedAmountSpent"`
+}
+
+// ConfirmFunds confirms whether the account has enough available to spend to cover the
+// target amount, given in minor units of the account currency.
+//
+// Note: ConfirmFunds uses the v2 API which is still under active development.
+func (c *Client) ConfirmFunds(ctx context.Context, act string, target int64) (*FundsConfirmation, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/accounts/"+act+"/confirmation-of-funds", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("targetAmountInMinorUnits", strconv.FormatInt(target, 10))
+	req.URL.RawQuery = q.Encode()
+
+	var fc *FundsConfirmation
+	resp, err := c.Do(ctx, req, &fc)
+	return fc, resp, err
+}
+
+// PaymentFunding reports whether a proposed payment can be funded
+type PaymentFunding struct {
+	Payment   LocalPayment
+	Amount    Amount
+	Funded    bool
+	Overdrawn bool   // The account is overdrawn once the payment is made
+	Remaining Amount // Available to spend once the payment is made
+}
+
+// FundingReport reports which of a list of proposed payments can be funded
+type FundingReport struct {
+	Available Amount // Available to spend before any payment is made
+	Remaining Amount // Available to spend once the funded payments are made
+	Payments  []PaymentFunding
+}
+
+// Funded returns the payments that can be funded.
+func (r FundingReport) Funded() []LocalPayment {
+	ps := []LocalPayment{}
+	for _, pf := range r.Payments {
+		if pf.Funded {
+			ps = append(ps, pf.Payment)
+		}
+	}
+	return ps
+}
+
+// FundPayments reports which of the proposed payments can be funded from the account if
+// they are made in order. Available to spend, including any accepted overdraft, is taken
+// from the current account balance. A payment that cannot be funded is skipped and does
+// not prevent later, smaller payments from being funded. Payments in a currency other than
+// that of the account are not funded.
+func (c *Client) FundPayments(ctx context.Context, act string, payments []LocalPayment) (*FundingReport, *http.Response, error) {
+	b, resp, err := c.AccountBalances(ctx, act)
+	if err != nil {
+		return nil, resp, errors.Wrap(err, "unable to retrieve balance")
+	}
+	if b == nil {
+		return nil, resp, errors.New("unable to retrieve balance: no balance returned")
+	}
+
+	cur := b.Available.Currency
+	avail := b.Available.MinorUnits
+	overdraft := b.Overdraft.MinorUnits
+
+	r := &FundingReport{Available: b.Available, Payments: make([]PaymentFunding, len(payments))}
+	for i, p := range payments {
+		amt := Amount{Currency: p.Payment.Currency, MinorUnits: MinorUnits(p.Payment.Amount, p.Payment.Currency)}
+		pf := PaymentFunding{Payment: p, Amount: amt}
+
+		if amt.Currency == cur && amt.MinorUnits <= avail {
+			avail -= amt.MinorUnits
+			pf.Funded = true
+			pf.Overdrawn = avail < overdraft
+		}
+
+		pf.Remaining = Amount{Currency: cur, MinorUnits: avail}
+		r.Payments[i] = pf
+	}
+
+	r.Remaining = Amount{Currency: cur, MinorUnits: avail}
+	return r, resp, nil
+}
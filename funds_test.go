@@ -0,0 +1,99 @@
+package starling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestConfirmFunds(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/accounts/24492cc9-77dd-4155-87a2-ec2580daf139/confirmation-of-funds", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodGet)
+
+		if got := r.URL.Query().Get("targetAmountInMinorUnits"); got != "12345" {
+			t.Error("should send the target amount in minor units", cross, got)
+		}
+
+		fmt.Fprint(w, `{"requestedAmountAvailableToSpend": true, "accountWouldBeInOverdraftIfRequestedAmountSpent": true}`)
+	})
+
+	got, _, err := client.ConfirmFunds(context.Background(), "24492cc9-77dd-4155-87a2-ec2580daf139", 12345)
+	checkNoError(t, err)
+
+	if !got.Available || !got.WouldBeOverdrawn {
+		t.Error("should return a confirmation matching the mock response", cross, got)
+	}
+}
+
+func TestConfirmFundsForbidden(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/accounts/24492cc9-77dd-4155-87a2-ec2580daf139/confirmation-of-funds", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	got, resp, err := client.ConfirmFunds(context.Background(), "24492cc9-77dd-4155-87a2-ec2580daf139", 12345)
+	checkHasError(t, err)
+	checkStatus(t, resp, http.StatusForbidden)
+
+	if got != nil {
+		t.Error("should not return a confirmation", cross)
+	}
+}
+
+func TestFundPayments(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/accounts/24492cc9-77dd-4155-87a2-ec2580daf139/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, balancesMock)
+	})
+
+	gbp := func(v float64) LocalPayment {
+		return LocalPayment{Payment: PaymentAmount{Currency: "GBP", Amount: v}}
+	}
+
+	payments := []LocalPayment{
+		gbp(50.00),
+		gbp(100.00),
+		gbp(600.00),
+		{Payment: PaymentAmount{Currency: "EUR", Amount: 1.00}},
+		gbp(350.00),
+	}
+
+	got, _, err := client.FundPayments(context.Background(), "24492cc9-77dd-4155-87a2-ec2580daf139", payments)
+	checkNoError(t, err)
+
+	// 600.00 is available to spend, including the 500.00 overdraft.
+	want := []struct {
+		funded    bool
+		overdrawn bool
+		remaining int64
+	}{
+		{true, false, 55000},
+		{true, true, 45000},
+		{false, false, 45000},
+		{false, false, 45000},
+		{true, true, 10000},
+	}
+
+	for i, w := range want {
+		pf := got.Payments[i]
+		if pf.Funded != w.funded || pf.Overdrawn != w.overdrawn || pf.Remaining.MinorUnits != w.remaining {
+			t.Error("should report whether the payment can be funded in order", cross, i, pf)
+		}
+	}
+
+	if got.Remaining != (Amount{Currency: "GBP", MinorUnits: 10000}) {
+		t.Error("should report the amount remaining once the payments are made", cross, got.Remaining)
+	}
+
+	if len(got.Funded()) != 3 {
+		t.Error("should list the payments that can be funded", cross, got.Funded())
+	}
+}
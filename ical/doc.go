@@ -0,0 +1,13 @@
+/*
+Package ical builds RFC 5545 iCalendar documents of the upcoming payments on a Starling
+account so that they can be shown in calendar clients.
+
+Each payment order, direct debit mandate and savings goal is written as a single
+recurring event. Event UIDs are derived from the Starling UIDs so that calendar clients
+update existing events rather than creating duplicates when a feed is refreshed:
+
+	cal := ical.New("Starling payments")
+	err := cal.AddPaymentOrders(orders)
+	cal.WriteTo(w)
+*/
+package ical
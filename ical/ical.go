@@ -0,0 +1,162 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+const (
+	prodID     = "-//billglover//go-starling//EN"
+	uidDomain  = "go-starling"
+	lineLength = 75
+)
+
+// Event is a single, possibly recurring, calendar event
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time // Date of the first occurrence, written as an all-day event
+	RRULE       string    // Optional RFC 5545 recurrence rule without the RRULE: prefix
+	Amount      starling.Amount
+}
+
+// Calendar is a collection of events that can be written as an iCalendar document
+type Calendar struct {
+	Name      string
+	Timestamp time.Time // Written as the DTSTAMP of each event, defaults to the current time
+	events    []Event
+}
+
+// New returns an empty calendar with the given name.
+func New(name string) *Calendar {
+	return &Calendar{Name: name}
+}
+
+// Add adds an event to the calendar. An event with the same UID as an existing event
+// replaces it.
+func (c *Calendar) Add(e Event) {
+	for i := range c.events {
+		if c.events[i].UID == e.UID {
+			c.events[i] = e
+			return
+		}
+	}
+	c.events = append(c.events, e)
+}
+
+// Events returns the events in the calendar ordered by start date and UID.
+func (c *Calendar) Events() []Event {
+	evs := make([]Event, len(c.events))
+	copy(evs, c.events)
+	sort.SliceStable(evs, func(i, j int) bool {
+		if !evs[i].Start.Equal(evs[j].Start) {
+			return evs[i].Start.Before(evs[j].Start)
+		}
+		return evs[i].UID < evs[j].UID
+	})
+	return evs
+}
+
+// WriteTo writes the calendar to w as an iCalendar document. It returns the number of
+// bytes written.
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	stamp := c.Timestamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	buf := new(bytes.Buffer)
+	line := func(name, value string) {
+		writeLine(buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", prodID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+
+	for _, e := range c.Events() {
+		line("BEGIN", "VEVENT")
+		line("UID", e.UID)
+		line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", e.Start.Format("20060102"))
+		if e.RRULE != "" {
+			line("RRULE", e.RRULE)
+		}
+		line("SUMMARY", escape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escape(e.Description))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return buf.WriteTo(w)
+}
+
+// String returns the calendar as an iCalendar document.
+func (c *Calendar) String() string {
+	buf := new(bytes.Buffer)
+	c.WriteTo(buf)
+	return buf.String()
+}
+
+// writeLine writes a content line terminated by CRLF, folding it so that no line is
+// longer than 75 octets. Lines are only folded between UTF-8 characters.
+func writeLine(buf *bytes.Buffer, s string) {
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > lineLength {
+			buf.WriteString("\r\n ")
+			n = 1
+		}
+		buf.WriteRune(r)
+		n += size
+	}
+	buf.WriteString("\r\n")
+}
+
+// escaper escapes the characters that have special meaning in TEXT property values
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// escape escapes a TEXT property value.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// uid returns a stable event UID for a Starling resource.
+func uid(kind, id string) string {
+	return kind + "-" + strings.ToLower(id) + "@" + uidDomain
+}
+
+// formatAmount formats an amount as a decimal value, with as many decimal places as the
+// currency has minor units, followed by the currency code.
+func formatAmount(a starling.Amount) string {
+	v := a.MinorUnits
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+
+	e := starling.CurrencyExponent(a.Currency)
+	if e == 0 {
+		return fmt.Sprintf("%s%d %s", sign, v, a.Currency)
+	}
+
+	scale := int64(math.Pow10(e))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, v/scale, e, v%scale, a.Currency)
+}
@@ -0,0 +1,100 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+const (
+	tick  = "\u2713"
+	cross = "\u2717"
+)
+
+func TestWriteTo(t *testing.T) {
+	cal := New("Payments")
+	cal.Timestamp = time.Date(2019, 1, 31, 9, 30, 0, 0, time.UTC)
+	cal.Add(Event{
+		UID:         "payment-order-1@go-starling",
+		Summary:     "Rent, flat 1; monthly",
+		Description: "line one\nline two",
+		Start:       time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC),
+		RRULE:       "FREQ=MONTHLY",
+	})
+
+	got := cal.String()
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//billglover//go-starling//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Payments",
+		"BEGIN:VEVENT",
+		"UID:payment-order-1@go-starling",
+		"DTSTAMP:20190131T093000Z",
+		"DTSTART;VALUE=DATE:20190201",
+		"RRULE:FREQ=MONTHLY",
+		`SUMMARY:Rent\, flat 1\; monthly`,
+		`DESCRIPTION:line one\nline two`,
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n") + "\r\n"
+
+	if got != want {
+		t.Error("should write a valid iCalendar document", cross, got)
+	}
+}
+
+func TestWriteLineFolding(t *testing.T) {
+	cal := New("")
+	cal.Add(Event{UID: "1", Summary: strings.Repeat("é", 60), Start: time.Now()})
+
+	for _, l := range strings.Split(cal.String(), "\r\n") {
+		if len(l) > lineLength {
+			t.Error("should fold lines longer than 75 octets", cross, len(l))
+		}
+	}
+
+	if !strings.Contains(cal.String(), "\r\n é") {
+		t.Error("should continue folded lines with a space", cross)
+	}
+}
+
+func TestAddReplacesEvent(t *testing.T) {
+	cal := New("")
+	cal.Add(Event{UID: "1", Summary: "first"})
+	cal.Add(Event{UID: "2", Summary: "second"})
+	cal.Add(Event{UID: "1", Summary: "updated"})
+
+	evs := cal.Events()
+	if len(evs) != 2 {
+		t.Fatal("should replace an event with the same UID", cross, len(evs))
+	}
+
+	if evs[0].Summary != "updated" {
+		t.Error("should keep the latest version of the event", cross, evs[0].Summary)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tcs := []struct {
+		a    starling.Amount
+		want string
+	}{
+		{a: starling.Amount{Currency: "GBP", MinorUnits: 1655}, want: "16.55 GBP"},
+		{a: starling.Amount{Currency: "EUR", MinorUnits: 5}, want: "0.05 EUR"},
+		{a: starling.Amount{Currency: "GBP", MinorUnits: -1200}, want: "-12.00 GBP"},
+		{a: starling.Amount{Currency: "JPY", MinorUnits: 1500}, want: "1500 JPY"},
+		{a: starling.Amount{Currency: "KWD", MinorUnits: 12345}, want: "12.345 KWD"},
+	}
+
+	for _, tc := range tcs {
+		if got := formatAmount(tc.a); got != tc.want {
+			t.Error("should format the amount", cross, got)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package ical
+
+import (
+	"time"
+
+	"github.com/billglover/starling"
+	"github.com/billglover/starling/insights"
+	"github.com/pkg/errors"
+)
+
+// rules maps the frequencies inferred from direct debit history to recurrence rules
+var rules = map[insights.Frequency]starling.RecurrenceRule{
+	insights.Weekly:      {Frequency: starling.FrequencyWeekly},
+	insights.Fortnightly: {Frequency: starling.FrequencyWeekly, Interval: 2},
+	insights.Monthly:     {Frequency: starling.FrequencyMonthly},
+	insights.Quarterly:   {Frequency: starling.FrequencyMonthly, Interval: 3},
+	insights.Annually:    {Frequency: starling.FrequencyYearly},
+}
+
+// AddPaymentOrders adds an event for each scheduled payment order. Cancelled orders and
+// immediate payments are skipped. Orders with a recurrence rule are added as recurring
+// events, other orders as a single event on their next date. An error is returned if the
+// schedule of an order cannot be converted.
+func (c *Calendar) AddPaymentOrders(orders []starling.PaymentOrder) error {
+	for _, po := range orders {
+		if po.CancelledAt != "" || po.Immediate {
+			continue
+		}
+
+		amt := starling.Amount{Currency: po.Currency, MinorUnits: starling.MinorUnits(po.Amount, po.Currency)}
+		name := po.RecipientName
+		if name == "" {
+			name = po.Reference
+		}
+
+		e := Event{
+			UID:         uid("payment-order", po.UID),
+			Summary:     name + ": " + formatAmount(amt),
+			Description: "Reference: " + po.Reference,
+			Amount:      amt,
+		}
+
+		start := po.NextDate
+		if r := po.RecurrenceRule; r.Frequency != "" {
+			if r.StartDate == "" {
+				r.StartDate = po.StartDate
+			}
+			rrule, err := r.RRULE()
+			if err != nil {
+				return errors.Wrap(err, "unable to convert schedule for payment order "+po.UID)
+			}
+			e.RRULE, start = rrule, r.StartDate
+		}
+
+		d, err := time.Parse("2006-01-02", start)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse start date for payment order "+po.UID)
+		}
+		e.Start = d
+
+		c.Add(e)
+	}
+	return nil
+}
+
+// AddMandates adds an event for each live direct debit mandate with a regular history of
+// payments. Starling does not publish the dates on which direct debits will be collected,
+// so the start date, frequency and amount are predicted from the direct debit history as
+// of now. Mandates without enough history to predict the next payment are skipped. An
+// error is returned if the history cannot be parsed.
+func (c *Calendar) AddMandates(mandates []starling.DirectDebitMandate, history []starling.DDTransaction, now time.Time) error {
+	txns, err := insights.FromDirectDebits(history)
+	if err != nil {
+		return err
+	}
+	series := insights.DetectRecurring(txns, now, &insights.RecurringOpts{MinOccurrences: 2})
+
+	for _, m := range mandates {
+		if m.Cancelled != "" || m.Status == "CANCELLED" {
+			continue
+		}
+
+		for _, s := range series {
+			if s.CounterParty != m.UID || s.Stopped {
+				continue
+			}
+
+			// A mandate may be used to collect more than one series of payments, so the
+			// frequency and first charge of the series are used to give each event its own
+			// UID.
+			id := m.UID + "-" + string(s.Frequency) + "-" + s.Charges[0].UID
+
+			r := rules[s.Frequency]
+			r.StartDate = s.NextDate.Format("2006-01-02")
+			rrule, err := r.RRULE()
+			if err != nil {
+				return errors.Wrap(err, "unable to convert schedule for mandate "+m.UID)
+			}
+
+			c.Add(Event{
+				UID:         uid("mandate", id),
+				Summary:     m.OriginatorName + ": " + formatAmount(s.NextAmount) + " (estimated)",
+				Description: "Direct debit reference: " + m.Reference,
+				Start:       s.NextDate,
+				RRULE:       rrule,
+				Amount:      s.NextAmount,
+			})
+		}
+	}
+	return nil
+}
+
+// AddSavingsTransfers adds an event for the recurring transfer into each savings goal.
+// Transfers are keyed by savings goal UID and the goals are used to name the events. An
+// error is returned if the schedule or start date of a transfer cannot be converted.
+func (c *Calendar) AddSavingsTransfers(goals []starling.SavingsGoal, transfers map[string]starling.RecurringTransferRequest) error {
+	names := map[string]string{}
+	for _, g := range goals {
+		names[g.UID] = g.Name
+	}
+
+	for goalUID, rt := range transfers {
+		rrule, err := rt.RecurrenceRule.RRULE()
+		if err != nil {
+			return errors.Wrap(err, "unable to convert schedule for savings goal "+goalUID)
+		}
+
+		start, err := time.Parse("2006-01-02", rt.RecurrenceRule.StartDate)
+		if err != nil {
+			return errors.Wrap(err, "unable to parse start date for savings goal "+goalUID)
+		}
+
+		name := names[goalUID]
+		if name == "" {
+			name = "Savings goal"
+		}
+
+		c.Add(Event{
+			UID:     uid("savings-goal", goalUID),
+			Summary: "Transfer to " + name + ": " + formatAmount(rt.Amount),
+			Start:   start,
+			RRULE:   rrule,
+			Amount:  rt.Amount,
+		})
+	}
+	return nil
+}
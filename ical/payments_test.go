@@ -0,0 +1,185 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+func TestAddPaymentOrders(t *testing.T) {
+	orders := []starling.PaymentOrder{
+		{
+			UID:            "1E22A383-0dd6-4845-a5fd-17c55920381d",
+			Currency:       "GBP",
+			Amount:         600,
+			Reference:      "Rent",
+			RecipientName:  "Landlord",
+			StartDate:      "2019-01-31",
+			RecurrenceRule: starling.RecurrenceRule{Frequency: "MONTHLY", Interval: 1},
+		},
+		{
+			UID:       "f8e714f1-f5a3-4bd8-a6f5-28e44a6b1416",
+			Currency:  "GBP",
+			Amount:    10.24,
+			Reference: "Dinner",
+			NextDate:  "2019-02-14",
+		},
+		{UID: "immediate", Immediate: true, NextDate: "2019-02-14"},
+		{UID: "cancelled", CancelledAt: "2019-01-01T00:00:00.000Z", NextDate: "2019-02-14"},
+	}
+
+	cal := New("")
+	if err := cal.AddPaymentOrders(orders); err != nil {
+		t.Fatal("should add payment orders without error", cross, err)
+	}
+
+	evs := cal.Events()
+	if len(evs) != 2 {
+		t.Fatal("should skip immediate and cancelled payment orders", cross, len(evs))
+	}
+
+	if evs[0].UID != "payment-order-1e22a383-0dd6-4845-a5fd-17c55920381d@go-starling" {
+		t.Error("should derive a stable UID from the payment order UID", cross, evs[0].UID)
+	}
+
+	if evs[0].RRULE != "FREQ=MONTHLY" || !evs[0].Start.Equal(time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Error("should add a recurring event from the order start date", cross, evs[0].RRULE, evs[0].Start)
+	}
+
+	if evs[0].Summary != "Landlord: 600.00 GBP" {
+		t.Error("should summarise the recipient and amount", cross, evs[0].Summary)
+	}
+
+	if evs[1].RRULE != "" || !evs[1].Start.Equal(time.Date(2019, 2, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Error("should add a single event on the next date", cross, evs[1].RRULE, evs[1].Start)
+	}
+
+	// Refreshing the calendar should not duplicate events.
+	cal.AddPaymentOrders(orders)
+	if len(cal.Events()) != 2 {
+		t.Error("should not duplicate events when orders are added again", cross)
+	}
+
+	bad := []starling.PaymentOrder{{UID: "bad", RecurrenceRule: starling.RecurrenceRule{Frequency: "SOMETIMES", StartDate: "2019-01-01"}}}
+	if err := New("").AddPaymentOrders(bad); err == nil {
+		t.Error("should return an error for an invalid schedule", cross)
+	}
+}
+
+func TestAddMandates(t *testing.T) {
+	mandates := []starling.DirectDebitMandate{
+		{UID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d", Status: "LIVE", OriginatorName: "Energy Co", Reference: "ACC123"},
+		{UID: "no-history", Status: "LIVE", OriginatorName: "New Co"},
+	}
+	history := []starling.DDTransaction{
+		{UID: "1", Currency: "GBP", Amount: -42.13, Created: "2018-12-10T23:30:00.000Z", MandateUID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d"},
+		{UID: "2", Currency: "GBP", Amount: -42.13, Created: "2019-01-10T23:30:00.000Z", MandateUID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d"},
+	}
+
+	cal := New("")
+	if err := cal.AddMandates(mandates, history, time.Date(2019, 1, 20, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal("should add mandates without error", cross, err)
+	}
+
+	evs := cal.Events()
+	if len(evs) != 1 {
+		t.Fatal("should skip mandates without a predictable history", cross, len(evs))
+	}
+
+	e := evs[0]
+	if e.UID != "mandate-7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d-monthly-1@go-starling" {
+		t.Error("should derive a stable UID from the mandate and series", cross, e.UID)
+	}
+
+	if e.RRULE != "FREQ=MONTHLY" || e.Start.Day() != 10 || e.Start.Month() != time.February {
+		t.Error("should start on the predicted date of the next direct debit", cross, e.RRULE, e.Start)
+	}
+
+	if !strings.Contains(e.Summary, "42.13 GBP") {
+		t.Error("should include the predicted amount", cross, e.Summary)
+	}
+}
+
+func TestAddMandatesSeries(t *testing.T) {
+	mandates := []starling.DirectDebitMandate{
+		{UID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d", Status: "LIVE", OriginatorName: "Energy Co"},
+	}
+	history := []starling.DDTransaction{
+		{UID: "1", Currency: "GBP", Amount: -42.13, Created: "2018-12-10T23:30:00.000Z", MandateUID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d"},
+		{UID: "2", Currency: "GBP", Amount: -42.13, Created: "2019-01-10T23:30:00.000Z", MandateUID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d"},
+		{UID: "3", Currency: "GBP", Amount: -200.00, Created: "2018-10-05T23:30:00.000Z", MandateUID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d"},
+		{UID: "4", Currency: "GBP", Amount: -200.00, Created: "2019-01-05T23:30:00.000Z", MandateUID: "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d"},
+	}
+
+	cal := New("")
+	if err := cal.AddMandates(mandates, history, time.Date(2019, 1, 20, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal("should add mandates without error", cross, err)
+	}
+
+	evs := cal.Events()
+	if len(evs) != 2 || evs[0].UID == evs[1].UID {
+		t.Fatal("should add an event with its own UID for each series of payments", cross, evs)
+	}
+
+	// Reordering the history must not change the UID given to each series.
+	history[0], history[2] = history[2], history[0]
+	history[1], history[3] = history[3], history[1]
+	reordered := New("")
+	if err := reordered.AddMandates(mandates, history, time.Date(2019, 1, 20, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal("should add mandates without error", cross, err)
+	}
+
+	uids := map[string]bool{}
+	for _, e := range reordered.Events() {
+		uids[e.UID] = true
+	}
+	for _, e := range evs {
+		if !uids[e.UID] {
+			t.Error("should give each series a UID that does not depend on its position", cross, e.UID)
+		}
+	}
+}
+
+func TestAddSavingsTransfers(t *testing.T) {
+	goals := []starling.SavingsGoal{{UID: "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", Name: "Trip to Paris"}}
+	transfers := map[string]starling.RecurringTransferRequest{
+		"e43d3060-2c83-4bb9-ac8c-c627b9c45f8b": {
+			RecurrenceRule: starling.RecurrenceRule{StartDate: "2017-09-23", Frequency: "WEEKLY", WeekStart: "MONDAY"},
+			Amount:         starling.Amount{Currency: "GBP", MinorUnits: 1000},
+		},
+	}
+
+	cal := New("")
+	if err := cal.AddSavingsTransfers(goals, transfers); err != nil {
+		t.Fatal("should add savings transfers without error", cross, err)
+	}
+
+	evs := cal.Events()
+	if len(evs) != 1 {
+		t.Fatal("should add an event for each recurring transfer", cross, len(evs))
+	}
+
+	if evs[0].Summary != "Transfer to Trip to Paris: 10.00 GBP" || evs[0].RRULE != "FREQ=WEEKLY;WKST=MO" {
+		t.Error("should describe the recurring transfer", cross, evs[0].Summary, evs[0].RRULE)
+	}
+}
+
+func TestAddSavingsTransfersInvalidStart(t *testing.T) {
+	transfers := map[string]starling.RecurringTransferRequest{
+		"e43d3060-2c83-4bb9-ac8c-c627b9c45f8b": {
+			RecurrenceRule: starling.RecurrenceRule{StartDate: "23/09/2017", Frequency: "WEEKLY"},
+			Amount:         starling.Amount{Currency: "GBP", MinorUnits: 1000},
+		},
+	}
+
+	cal := New("")
+	if err := cal.AddSavingsTransfers(nil, transfers); err == nil {
+		t.Error("should return an error if the start date cannot be parsed", cross)
+	}
+
+	if len(cal.Events()) != 0 {
+		t.Error("should not add an event without a start date", cross, cal.Events())
+	}
+}
@@ -0,0 +1,88 @@
+package insights
+
+import (
+	"context"
+	"sort"
+
+	"github.com/billglover/starling"
+	"github.com/pkg/errors"
+)
+
+// Discrepancy records a difference between a local aggregation and the spending
+// insights calculated by Starling
+type Discrepancy struct {
+	Key            string
+	LocalSpent     starling.Amount
+	RemoteSpent    starling.Amount
+	LocalReceived  starling.Amount
+	RemoteReceived starling.Amount
+}
+
+// Compare compares locally aggregated groups with the breakdown returned by one of the
+// Starling spending insights endpoints. The dimension must be either ByCategory or
+// ByCounterParty and match the breakdown requested. Only groups in the currency of the
+// insights are considered. A discrepancy is returned for every key where the totals
+// differ, ordered by key.
+func Compare(local []Group, remote *starling.SpendingInsights, d Dimension) []Discrepancy {
+	cur := remote.Currency
+	lines := map[string]*Discrepancy{}
+	line := func(k string) *Discrepancy {
+		if l, ok := lines[k]; ok {
+			return l
+		}
+		l := &Discrepancy{
+			Key:            k,
+			LocalSpent:     starling.Amount{Currency: cur},
+			RemoteSpent:    starling.Amount{Currency: cur},
+			LocalReceived:  starling.Amount{Currency: cur},
+			RemoteReceived: starling.Amount{Currency: cur},
+		}
+		lines[k] = l
+		return l
+	}
+
+	for _, g := range local {
+		if g.Currency != cur {
+			continue
+		}
+		l := line(g.Key)
+		l.LocalSpent.MinorUnits += g.Spent.MinorUnits
+		l.LocalReceived.MinorUnits += g.Received.MinorUnits
+	}
+
+	for _, b := range remote.Breakdown {
+		k := b.SpendingCategory
+		if d == ByCounterParty {
+			k = b.CounterPartyUID
+		}
+		l := line(k)
+		l.RemoteSpent.MinorUnits += starling.MinorUnits(b.TotalSpent, cur)
+		l.RemoteReceived.MinorUnits += starling.MinorUnits(b.TotalReceived, cur)
+	}
+
+	ds := []Discrepancy{}
+	for _, l := range lines {
+		if l.LocalSpent != l.RemoteSpent || l.LocalReceived != l.RemoteReceived {
+			ds = append(ds, *l)
+		}
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i].Key < ds[j].Key })
+	return ds
+}
+
+// CompareCategories retrieves the spending by category for an account and month from
+// Starling and compares it against the given transactions. Transactions outside of the
+// month are ignored. An error is returned if unable to retrieve the spending insights.
+func CompareCategories(ctx context.Context, c *starling.Client, act string, m Month, txns []Txn) ([]Discrepancy, error) {
+	si, _, err := c.SpendingByCategory(ctx, act, m.Year, m.Month)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to retrieve spending insights")
+	}
+
+	if si == nil {
+		return nil, errors.New("no spending insights returned")
+	}
+
+	txns = Filter(txns, func(t Txn) bool { return m.Contains(t.Time) })
+	return Compare(GroupBy(txns, ByCategory), si, ByCategory), nil
+}
@@ -0,0 +1,73 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+func TestCompare(t *testing.T) {
+	local := GroupBy(groupTxns, ByCategory)
+	remote := &starling.SpendingInsights{
+		Currency: "GBP",
+		Breakdown: []starling.SpendingBreakdown{
+			{SpendingCategory: "EATING_OUT", TotalSpent: 8.00},
+			{SpendingCategory: "GROCERIES", TotalSpent: 74.98},
+			{SpendingCategory: "INCOME", TotalReceived: 2000},
+			{SpendingCategory: "TRANSPORT", TotalSpent: 2.40},
+		},
+	}
+
+	got := Compare(local, remote, ByCategory)
+
+	if len(got) != 1 {
+		t.Fatal("should only return categories where the totals differ", cross, got)
+	}
+
+	if got[0].Key != "TRANSPORT" || got[0].RemoteSpent != gbp(240) || got[0].LocalSpent != gbp(0) {
+		t.Error("should report categories missing locally", cross, got[0])
+	}
+}
+
+func TestCompareCategories(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	act := "24492cc9-77dd-4155-87a2-ec2580daf139"
+	mux.HandleFunc("/api/v2/accounts/"+act+"/spending-insights/spending-category", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("month") != "JANUARY" {
+			t.Error("should request the given month", cross, r.URL.Query().Get("month"))
+		}
+		fmt.Fprint(w, `{
+			"currency": "GBP",
+			"breakdown": [
+				{ "spendingCategory": "EATING_OUT", "totalSpent": 8.5, "totalReceived": 0 },
+				{ "spendingCategory": "GROCERIES", "totalSpent": 54.99, "totalReceived": 0 },
+				{ "spendingCategory": "INCOME", "totalSpent": 0, "totalReceived": 2000 }
+			]
+		}`)
+	})
+
+	u, _ := url.Parse(server.URL + "/")
+	client := starling.NewClientWithOptions(nil, starling.ClientOptions{BaseURL: u})
+
+	got, err := CompareCategories(context.Background(), client, act, Month{Year: 2019, Month: time.January}, groupTxns)
+	if err != nil {
+		t.Fatal("should compare without error", cross, err)
+	}
+
+	if len(got) != 1 {
+		t.Fatal("should ignore transactions outside of the month", cross, got)
+	}
+
+	if got[0].Key != "EATING_OUT" || got[0].LocalSpent != gbp(800) || got[0].RemoteSpent != gbp(850) {
+		t.Error("should report the difference", cross, got[0])
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Package insights provides spending analytics built on top of the Starling API client.
+
+Transactions retrieved from the different Starling endpoints are first normalised into
+a common Txn type:
+
+	items, _, err := client.Feed(ctx, act, cat, nil)
+	txns := insights.FromFeed(items)
+
+They can then be grouped by spending category, merchant, counter-party or month, with
+totals held as exact minor-unit amounts:
+
+	for _, g := range insights.GroupBy(txns, insights.ByCategory) {
+		fmt.Println(g.Key, g.Spent.MinorUnits, g.Count)
+	}
+
+Local aggregations can be compared against the spending insights calculated by
+Starling using CompareCategories.
+
+DetectRecurring looks for subscriptions and other charges that repeat at a regular
+interval, predicting the date and amount of the next charge:
+
+	for _, r := range insights.DetectRecurring(txns, time.Now(), nil) {
+		fmt.Println(r.CounterParty, r.Frequency, r.NextDate, r.NextAmount.MinorUnits)
+	}
+*/
+package insights
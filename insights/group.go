@@ -0,0 +1,136 @@
+package insights
+
+import (
+	"sort"
+
+	"github.com/billglover/starling"
+)
+
+// Dimension identifies the attribute used to group transactions
+type Dimension int
+
+// Dimensions by which transactions can be grouped
+const (
+	ByCategory Dimension = iota
+	ByMerchant
+	ByCounterParty
+	ByMonth
+)
+
+// Group holds the totals for a set of transactions that share the same key and currency
+type Group struct {
+	Key           string
+	Currency      string
+	SpentCount    int // Number of outbound transactions
+	ReceivedCount int // Number of inbound transactions
+	Spent         starling.Amount
+	Received      starling.Amount
+}
+
+// Count returns the total number of transactions in the group.
+func (g Group) Count() int {
+	return g.SpentCount + g.ReceivedCount
+}
+
+// Net returns the amount spent less the amount received. A negative value indicates
+// that more was received than spent.
+func (g Group) Net() starling.Amount {
+	return starling.Amount{Currency: g.Currency, MinorUnits: g.Spent.MinorUnits - g.Received.MinorUnits}
+}
+
+// AverageSpend returns the mean value of the outbound transactions in the group,
+// rounded down to the nearest minor unit.
+func (g Group) AverageSpend() starling.Amount {
+	a := starling.Amount{Currency: g.Currency}
+	if g.SpentCount != 0 {
+		a.MinorUnits = g.Spent.MinorUnits / int64(g.SpentCount)
+	}
+	return a
+}
+
+// Key returns the value of the given dimension for a transaction. An empty key
+// indicates that the transaction has no value for the dimension.
+func (t Txn) Key(d Dimension) string {
+	switch d {
+	case ByCategory:
+		return t.Category
+	case ByMerchant:
+		return t.MerchantUID
+	case ByCounterParty:
+		return t.CounterParty
+	case ByMonth:
+		return MonthOf(t.Time).String()
+	}
+	return ""
+}
+
+// GroupBy groups transactions by the given dimension and returns the totals for each
+// group. Transactions in different currencies are never combined. Groups are ordered by
+// the amount spent, largest first. Transactions without a merchant are excluded when
+// grouping by merchant.
+func GroupBy(txns []Txn, d Dimension) []Group {
+	type groupKey struct{ key, currency string }
+
+	idx := map[groupKey]int{}
+	groups := []Group{}
+
+	for _, t := range txns {
+		k := groupKey{t.Key(d), t.Amount.Currency}
+		if d == ByMerchant && k.key == "" {
+			continue
+		}
+
+		i, ok := idx[k]
+		if !ok {
+			i = len(groups)
+			idx[k] = i
+			groups = append(groups, Group{
+				Key:      k.key,
+				Currency: k.currency,
+				Spent:    starling.Amount{Currency: k.currency},
+				Received: starling.Amount{Currency: k.currency},
+			})
+		}
+
+		g := &groups[i]
+		if t.Direction == In {
+			g.ReceivedCount++
+			g.Received.MinorUnits += t.Amount.MinorUnits
+		} else {
+			g.SpentCount++
+			g.Spent.MinorUnits += t.Amount.MinorUnits
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Spent.MinorUnits != groups[j].Spent.MinorUnits {
+			return groups[i].Spent.MinorUnits > groups[j].Spent.MinorUnits
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// TopMerchants returns the n merchants with the highest spend. Fewer than n groups are
+// returned if there are not enough merchants, and none if n is negative.
+func TopMerchants(txns []Txn, n int) []Group {
+	groups := GroupBy(txns, ByMerchant)
+	if n < 0 {
+		n = 0
+	}
+	if n < len(groups) {
+		groups = groups[:n]
+	}
+	return groups
+}
+
+// Filter returns the transactions for which the keep function returns true.
+func Filter(txns []Txn, keep func(Txn) bool) []Txn {
+	out := []Txn{}
+	for _, t := range txns {
+		if keep(t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package insights
+
+import (
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+func gbp(v int64) starling.Amount {
+	return starling.Amount{Currency: "GBP", MinorUnits: v}
+}
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 12, 0, 0, 0, time.UTC)
+}
+
+var groupTxns = []Txn{
+	{UID: "1", Time: day(2019, 1, 3), Direction: Out, Amount: gbp(450), Category: "EATING_OUT", MerchantUID: "coffee", CounterParty: "coffee"},
+	{UID: "2", Time: day(2019, 1, 9), Direction: Out, Amount: gbp(350), Category: "EATING_OUT", MerchantUID: "coffee", CounterParty: "coffee"},
+	{UID: "3", Time: day(2019, 1, 12), Direction: Out, Amount: gbp(5499), Category: "GROCERIES", MerchantUID: "grocer", CounterParty: "grocer"},
+	{UID: "4", Time: day(2019, 1, 25), Direction: In, Amount: gbp(200000), Category: "INCOME", CounterParty: "employer"},
+	{UID: "5", Time: day(2019, 2, 2), Direction: Out, Amount: gbp(1999), Category: "GROCERIES", MerchantUID: "grocer", CounterParty: "grocer"},
+	{UID: "6", Time: day(2019, 2, 5), Direction: Out, Amount: starling.Amount{Currency: "EUR", MinorUnits: 1000}, Category: "GROCERIES", MerchantUID: "grocer", CounterParty: "grocer"},
+}
+
+func TestGroupByCategory(t *testing.T) {
+	got := GroupBy(groupTxns, ByCategory)
+
+	if len(got) != 4 {
+		t.Fatal("should return a group for each category and currency", cross, len(got))
+	}
+
+	if got[0].Key != "GROCERIES" || got[0].Currency != "GBP" {
+		t.Error("should order groups by the amount spent", cross, got[0].Key)
+	}
+
+	if got[0].Spent != gbp(7498) || got[0].SpentCount != 2 {
+		t.Error("should total the amount spent in exact minor units", cross, got[0].Spent)
+	}
+
+	var income Group
+	for _, g := range got {
+		if g.Key == "INCOME" {
+			income = g
+		}
+	}
+
+	if income.Received != gbp(200000) || income.Net() != gbp(-200000) {
+		t.Error("should total the amount received", cross, income.Received)
+	}
+}
+
+func TestGroupByMerchant(t *testing.T) {
+	got := GroupBy(groupTxns, ByMerchant)
+
+	for _, g := range got {
+		if g.Key == "" {
+			t.Error("should exclude transactions without a merchant", cross)
+		}
+	}
+
+	if len(got) != 3 {
+		t.Error("should return a group for each merchant and currency", cross, len(got))
+	}
+}
+
+func TestGroupByMonth(t *testing.T) {
+	got := GroupBy(groupTxns, ByMonth)
+
+	keys := map[string]bool{}
+	for _, g := range got {
+		keys[g.Key] = true
+	}
+
+	if !keys["2019-01"] || !keys["2019-02"] {
+		t.Error("should group by calendar month", cross, keys)
+	}
+}
+
+func TestTopMerchants(t *testing.T) {
+	got := TopMerchants(groupTxns, 1)
+
+	if len(got) != 1 {
+		t.Fatal("should limit the number of merchants returned", cross, len(got))
+	}
+
+	if got[0].Key != "grocer" {
+		t.Error("should return the merchant with the highest spend", cross, got[0].Key)
+	}
+
+	if got[0].AverageSpend() != gbp(3749) {
+		t.Error("should return the average spend", cross, got[0].AverageSpend())
+	}
+
+	if got := TopMerchants(groupTxns, 10); len(got) != 3 {
+		t.Error("should return all merchants when there are fewer than requested", cross, len(got))
+	}
+
+	if got := TopMerchants(groupTxns, -1); len(got) != 0 {
+		t.Error("should return no merchants when a negative number is requested", cross, len(got))
+	}
+}
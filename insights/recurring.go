@@ -0,0 +1,248 @@
+package insights
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+// Frequency is the interval at which a recurring charge repeats
+type Frequency string
+
+// Frequencies that can be inferred from transaction history
+const (
+	Weekly      Frequency = "WEEKLY"
+	Fortnightly Frequency = "FORTNIGHTLY"
+	Monthly     Frequency = "MONTHLY"
+	Quarterly   Frequency = "QUARTERLY"
+	Annually    Frequency = "ANNUALLY"
+)
+
+// band is the range of days between charges accepted for a frequency. The ranges allow
+// for charges that move to avoid weekends and bank holidays.
+type band struct {
+	freq     Frequency
+	min, max float64
+}
+
+var bands = []band{
+	{Weekly, 6, 8},
+	{Fortnightly, 12, 16},
+	{Monthly, 26, 35},
+	{Quarterly, 84, 98},
+	{Annually, 355, 375},
+}
+
+// RecurringOpts holds the options used when detecting recurring charges
+type RecurringOpts struct {
+	Tolerance      float64 // Relative change in amount accepted between consecutive charges, defaults to 0.25
+	MinOccurrences int     // Minimum number of charges before a series is reported, defaults to 3 (2 for annual charges)
+}
+
+// Recurring is a series of charges to the same counter-party at a regular interval
+type Recurring struct {
+	CounterParty   string
+	MerchantUID    string
+	Category       string
+	Frequency      Frequency
+	Charges        []Txn           // Charges in the series, oldest first
+	LastAmount     starling.Amount // Amount of the most recent charge
+	PreviousAmount starling.Amount // Amount of the charge before the most recent charge
+	NextDate       time.Time       // Predicted date of the next charge
+	NextAmount     starling.Amount // Predicted amount of the next charge
+	PriceIncrease  bool            // True if the most recent charge was higher than the one before
+	Stopped        bool            // True if the predicted charge is overdue
+}
+
+// Last returns the most recent charge in the series.
+func (r Recurring) Last() Txn {
+	return r.Charges[len(r.Charges)-1]
+}
+
+// DetectRecurring scans outbound transactions for charges that repeat at a regular
+// interval. Charges are grouped by counter-party and currency and then split into series
+// where consecutive amounts are within the configured tolerance, so a counter-party
+// with two subscriptions of different values is reported twice. A series is flagged
+// as stopped if no charge has been seen by now, allowing a grace period relative to the
+// frequency. If opts is nil the default options are used.
+func DetectRecurring(txns []Txn, now time.Time, opts *RecurringOpts) []Recurring {
+	o := RecurringOpts{Tolerance: 0.25, MinOccurrences: 3}
+	if opts != nil {
+		if opts.Tolerance > 0 {
+			o.Tolerance = opts.Tolerance
+		}
+		if opts.MinOccurrences > 0 {
+			o.MinOccurrences = opts.MinOccurrences
+		}
+	}
+
+	type seriesKey struct{ counterParty, currency string }
+	byParty := map[seriesKey][]Txn{}
+	keys := []seriesKey{}
+	for _, t := range txns {
+		if t.Direction != Out || t.CounterParty == "" {
+			continue
+		}
+		k := seriesKey{t.CounterParty, t.Amount.Currency}
+		if _, ok := byParty[k]; !ok {
+			keys = append(keys, k)
+		}
+		byParty[k] = append(byParty[k], t)
+	}
+
+	found := []Recurring{}
+	for _, k := range keys {
+		for _, s := range split(byParty[k], o.Tolerance) {
+			if r, ok := recurring(s, now, o.MinOccurrences); ok {
+				found = append(found, r)
+			}
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool { return found[i].NextDate.Before(found[j].NextDate) })
+	return found
+}
+
+// split orders charges by time and splits them into series where each charge is within
+// tolerance of the most recent charge in the series.
+func split(txns []Txn, tolerance float64) [][]Txn {
+	sort.SliceStable(txns, func(i, j int) bool { return txns[i].Time.Before(txns[j].Time) })
+
+	series := [][]Txn{}
+	for _, t := range txns {
+		matched := false
+		for i, s := range series {
+			last := s[len(s)-1].Amount.MinorUnits
+			if within(t.Amount.MinorUnits, last, tolerance) {
+				series[i] = append(s, t)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			series = append(series, []Txn{t})
+		}
+	}
+	return series
+}
+
+func within(v, ref int64, tolerance float64) bool {
+	if ref == 0 {
+		return v == 0
+	}
+	return math.Abs(float64(v-ref))/float64(ref) <= tolerance
+}
+
+// recurring determines whether a series of charges repeats at a known frequency.
+func recurring(s []Txn, now time.Time, minOccurrences int) (Recurring, bool) {
+	if len(s) < 2 {
+		return Recurring{}, false
+	}
+
+	gaps := make([]float64, len(s)-1)
+	for i := 1; i < len(s); i++ {
+		gaps[i-1] = s[i].Time.Sub(s[i-1].Time).Hours() / 24
+	}
+
+	b, ok := classify(gaps)
+	if !ok {
+		return Recurring{}, false
+	}
+
+	min := minOccurrences
+	if b.freq == Annually && min > 2 {
+		min = 2
+	}
+	if len(s) < min {
+		return Recurring{}, false
+	}
+
+	last, prev := s[len(s)-1], s[len(s)-2]
+	r := Recurring{
+		CounterParty:   last.CounterParty,
+		MerchantUID:    last.MerchantUID,
+		Category:       last.Category,
+		Frequency:      b.freq,
+		Charges:        s,
+		LastAmount:     last.Amount,
+		PreviousAmount: prev.Amount,
+		NextDate:       b.freq.Next(last.Time),
+		NextAmount:     last.Amount,
+		PriceIncrease:  last.Amount.MinorUnits > prev.Amount.MinorUnits,
+	}
+
+	grace := time.Duration((b.max-b.min)/2+1) * 24 * time.Hour
+	r.Stopped = now.After(r.NextDate.Add(grace))
+	return r, true
+}
+
+// classify returns the frequency band that contains every gap between charges.
+func classify(gaps []float64) (band, bool) {
+	for _, b := range bands {
+		matched := true
+		for _, g := range gaps {
+			if g < b.min || g > b.max {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return b, true
+		}
+	}
+	return band{}, false
+}
+
+// Next returns the date one period after t.
+func (f Frequency) Next(t time.Time) time.Time {
+	return f.add(t, 1)
+}
+
+// add returns the date n periods after t. Monthly periods are always calculated from t,
+// so that a charge on the 31st returns to the 31st after a shorter month.
+func (f Frequency) add(t time.Time, n int) time.Time {
+	switch f {
+	case Weekly:
+		return t.AddDate(0, 0, 7*n)
+	case Fortnightly:
+		return t.AddDate(0, 0, 14*n)
+	case Quarterly:
+		return addMonths(t, 3*n)
+	case Annually:
+		return addMonths(t, 12*n)
+	}
+	return addMonths(t, n)
+}
+
+// Projected returns the predicted dates of future charges in the series, up to and
+// including until. No dates are returned for a series that has stopped.
+func (r Recurring) Projected(until time.Time) []time.Time {
+	if r.Stopped {
+		return nil
+	}
+
+	dates := []time.Time{}
+	last := r.Last().Time
+	for n := 1; ; n++ {
+		d := r.Frequency.add(last, n)
+		if d.After(until) {
+			break
+		}
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+// addMonths adds n months to t. If the day of the month does not exist in the resulting
+// month the last day of that month is used, so 31 January plus one month is 28 or 29
+// February rather than early March.
+func addMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
@@ -0,0 +1,133 @@
+package insights
+
+import (
+	"testing"
+	"time"
+)
+
+func charges(party string, amounts []int64, dates ...time.Time) []Txn {
+	txns := make([]Txn, len(dates))
+	for i, d := range dates {
+		txns[i] = Txn{UID: party + d.String(), Time: d, Direction: Out, Amount: gbp(amounts[i]), CounterParty: party}
+	}
+	return txns
+}
+
+func TestDetectRecurringMonthly(t *testing.T) {
+	txns := charges("streaming", []int64{799, 799, 799, 899},
+		day(2019, 1, 31), day(2019, 2, 28), day(2019, 4, 1), day(2019, 4, 30))
+
+	// A one-off charge to the same counter-party should not break the series.
+	txns = append(txns, charges("streaming", []int64{2500}, day(2019, 3, 14))...)
+
+	got := DetectRecurring(txns, day(2019, 5, 10), nil)
+
+	if len(got) != 1 {
+		t.Fatal("should detect a single recurring series", cross, len(got))
+	}
+
+	r := got[0]
+	if r.Frequency != Monthly {
+		t.Error("should infer a monthly frequency", cross, r.Frequency)
+	}
+
+	if len(r.Charges) != 4 {
+		t.Error("should exclude charges outside of the amount tolerance", cross, len(r.Charges))
+	}
+
+	if !r.PriceIncrease || r.PreviousAmount != gbp(799) || r.NextAmount != gbp(899) {
+		t.Error("should flag the price increase", cross, r.PreviousAmount, r.NextAmount)
+	}
+
+	if want := day(2019, 5, 30); !r.NextDate.Equal(want) {
+		t.Error("should predict the next charge date", cross, r.NextDate)
+	}
+
+	if r.Stopped {
+		t.Error("should not flag an active series as stopped", cross)
+	}
+}
+
+func TestDetectRecurringWeekly(t *testing.T) {
+	txns := charges("gym", []int64{1000, 1000, 1000},
+		day(2019, 3, 1), day(2019, 3, 8), day(2019, 3, 15))
+
+	got := DetectRecurring(txns, day(2019, 6, 1), nil)
+
+	if len(got) != 1 || got[0].Frequency != Weekly {
+		t.Fatal("should infer a weekly frequency", cross, got)
+	}
+
+	if !got[0].Stopped {
+		t.Error("should flag a series with overdue charges as stopped", cross)
+	}
+}
+
+func TestDetectRecurringAnnual(t *testing.T) {
+	txns := charges("insurer", []int64{12000, 12600}, day(2018, 2, 28), day(2019, 2, 27))
+
+	got := DetectRecurring(txns, day(2019, 3, 1), nil)
+
+	if len(got) != 1 || got[0].Frequency != Annually {
+		t.Fatal("should infer an annual frequency from two charges", cross, got)
+	}
+
+	if want := day(2020, 2, 27); !got[0].NextDate.Equal(want) {
+		t.Error("should predict the next annual charge", cross, got[0].NextDate)
+	}
+}
+
+func TestDetectRecurringIrregular(t *testing.T) {
+	txns := charges("coffee", []int64{350, 350, 350, 350},
+		day(2019, 3, 1), day(2019, 3, 3), day(2019, 3, 19), day(2019, 4, 2))
+
+	if got := DetectRecurring(txns, day(2019, 4, 3), nil); len(got) != 0 {
+		t.Error("should not report irregular charges", cross, got)
+	}
+
+	if got := DetectRecurring(txns[:2], day(2019, 4, 3), &RecurringOpts{MinOccurrences: 5}); len(got) != 0 {
+		t.Error("should respect the minimum number of occurrences", cross, got)
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	tcs := []struct {
+		in   time.Time
+		n    int
+		want time.Time
+	}{
+		{in: day(2019, 1, 31), n: 1, want: day(2019, 2, 28)},
+		{in: day(2020, 1, 31), n: 1, want: day(2020, 2, 29)},
+		{in: day(2019, 12, 15), n: 1, want: day(2020, 1, 15)},
+		{in: day(2019, 8, 31), n: 3, want: day(2019, 11, 30)},
+	}
+
+	for _, tc := range tcs {
+		if got := addMonths(tc.in, tc.n); !got.Equal(tc.want) {
+			t.Error("should clamp to the end of the month", cross, tc.in, got)
+		}
+	}
+}
+
+func TestRecurringProjected(t *testing.T) {
+	txns := charges("rent", []int64{90000, 90000, 90000},
+		day(2019, 1, 31), day(2019, 2, 28), day(2019, 3, 31))
+
+	got := DetectRecurring(txns, day(2019, 4, 1), nil)
+	if len(got) != 1 {
+		t.Fatal("should detect a single recurring series", cross, len(got))
+	}
+
+	dates := got[0].Projected(day(2019, 7, 1))
+	want := []time.Time{day(2019, 4, 30), day(2019, 5, 31), day(2019, 6, 30)}
+
+	if len(dates) != len(want) {
+		t.Fatal("should project each charge up to the given date", cross, dates)
+	}
+
+	for i := range want {
+		if !dates[i].Equal(want[i]) {
+			t.Error("should project month-end charges without drifting", cross, dates[i])
+		}
+	}
+}
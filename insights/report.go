@@ -0,0 +1,188 @@
+package insights
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+// Month identifies a calendar month
+type Month struct {
+	Year  int
+	Month time.Month
+}
+
+// MonthOf returns the calendar month in which t falls, in the location of t.
+func MonthOf(t time.Time) Month {
+	return Month{Year: t.Year(), Month: t.Month()}
+}
+
+// String returns the month in the form YYYY-MM.
+func (m Month) String() string {
+	return fmt.Sprintf("%04d-%02d", m.Year, int(m.Month))
+}
+
+// Next returns the month following m.
+func (m Month) Next() Month {
+	if m.Month == time.December {
+		return Month{Year: m.Year + 1, Month: time.January}
+	}
+	return Month{Year: m.Year, Month: m.Month + 1}
+}
+
+// Prev returns the month preceding m.
+func (m Month) Prev() Month {
+	if m.Month == time.January {
+		return Month{Year: m.Year - 1, Month: time.December}
+	}
+	return Month{Year: m.Year, Month: m.Month - 1}
+}
+
+// Before reports whether m is earlier than o.
+func (m Month) Before(o Month) bool {
+	return m.Year < o.Year || (m.Year == o.Year && m.Month < o.Month)
+}
+
+// Days returns the number of days in the month.
+func (m Month) Days() int {
+	return time.Date(m.Year, m.Month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Contains reports whether t falls within the month.
+func (m Month) Contains(t time.Time) bool {
+	return MonthOf(t) == m
+}
+
+// MonthlyChange is the spending in a single month compared with the previous month
+type MonthlyChange struct {
+	Month    Month
+	Spent    starling.Amount
+	Received starling.Amount
+	Delta    starling.Amount // Change in the amount spent since the previous month
+	Percent  float64         // Percentage change in the amount spent; zero if nothing was spent in the previous month
+}
+
+// MonthOverMonth returns the spending for each month between the earliest and latest
+// transactions in the given currency. Months without any transactions are included with
+// zero totals so that the deltas are always relative to the preceding calendar month.
+func MonthOverMonth(txns []Txn, currency string) []MonthlyChange {
+	txns = Filter(txns, func(t Txn) bool { return t.Amount.Currency == currency })
+	if len(txns) == 0 {
+		return nil
+	}
+
+	first, last := MonthOf(txns[0].Time), MonthOf(txns[0].Time)
+	for _, t := range txns {
+		m := MonthOf(t.Time)
+		if m.Before(first) {
+			first = m
+		}
+		if last.Before(m) {
+			last = m
+		}
+	}
+
+	totals := map[string]Group{}
+	for _, g := range GroupBy(txns, ByMonth) {
+		totals[g.Key] = g
+	}
+
+	changes := []MonthlyChange{}
+	var prev int64
+	for m := first; !last.Before(m); m = m.Next() {
+		g := totals[m.String()]
+		mc := MonthlyChange{
+			Month:    m,
+			Spent:    starling.Amount{Currency: currency, MinorUnits: g.Spent.MinorUnits},
+			Received: starling.Amount{Currency: currency, MinorUnits: g.Received.MinorUnits},
+			Delta:    starling.Amount{Currency: currency},
+		}
+		if len(changes) != 0 {
+			mc.Delta.MinorUnits = mc.Spent.MinorUnits - prev
+			mc.Percent = percent(mc.Delta.MinorUnits, prev)
+		}
+		prev = mc.Spent.MinorUnits
+		changes = append(changes, mc)
+	}
+	return changes
+}
+
+// CategoryChange compares the spending in a category with the previous month
+type CategoryChange struct {
+	Group
+	Previous starling.Amount // Amount spent in the category in the previous month
+	Delta    starling.Amount // Change in the amount spent since the previous month
+	Percent  float64         // Percentage change in the amount spent; zero if nothing was spent in the previous month
+}
+
+// Report is a summary of the spending in a single month
+type Report struct {
+	Month             Month
+	Currency          string
+	Count             int
+	Spent             starling.Amount
+	Received          starling.Amount
+	AverageSpend      starling.Amount // Mean value of outbound transactions
+	AverageDailySpend starling.Amount // Amount spent divided by the number of days in the month
+	Categories        []CategoryChange
+	TopMerchants      []Group
+}
+
+// MonthlyReport builds a spending report for the given month and currency. Categories
+// are compared against the previous month, which must be included in txns for the
+// comparison to be meaningful. Up to top merchants are included in the report.
+func MonthlyReport(txns []Txn, m Month, currency string, top int) Report {
+	txns = Filter(txns, func(t Txn) bool { return t.Amount.Currency == currency })
+	cur := Filter(txns, func(t Txn) bool { return m.Contains(t.Time) })
+	prev := Filter(txns, func(t Txn) bool { return m.Prev().Contains(t.Time) })
+
+	r := Report{
+		Month:             m,
+		Currency:          currency,
+		Spent:             starling.Amount{Currency: currency},
+		Received:          starling.Amount{Currency: currency},
+		AverageSpend:      starling.Amount{Currency: currency},
+		AverageDailySpend: starling.Amount{Currency: currency},
+		Categories:        []CategoryChange{},
+		TopMerchants:      TopMerchants(cur, top),
+	}
+
+	var spentCount int
+	for _, t := range cur {
+		r.Count++
+		if t.Direction == In {
+			r.Received.MinorUnits += t.Amount.MinorUnits
+			continue
+		}
+		spentCount++
+		r.Spent.MinorUnits += t.Amount.MinorUnits
+	}
+	if spentCount != 0 {
+		r.AverageSpend.MinorUnits = r.Spent.MinorUnits / int64(spentCount)
+	}
+	r.AverageDailySpend.MinorUnits = r.Spent.MinorUnits / int64(m.Days())
+
+	previous := map[string]int64{}
+	for _, g := range GroupBy(prev, ByCategory) {
+		previous[g.Key] = g.Spent.MinorUnits
+	}
+
+	for _, g := range GroupBy(cur, ByCategory) {
+		p := previous[g.Key]
+		r.Categories = append(r.Categories, CategoryChange{
+			Group:    g,
+			Previous: starling.Amount{Currency: currency, MinorUnits: p},
+			Delta:    starling.Amount{Currency: currency, MinorUnits: g.Spent.MinorUnits - p},
+			Percent:  percent(g.Spent.MinorUnits-p, p),
+		})
+	}
+	return r
+}
+
+func percent(delta, base int64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return float64(delta) / float64(base) * 100
+}
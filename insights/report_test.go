@@ -0,0 +1,83 @@
+package insights
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMonth(t *testing.T) {
+	m := Month{Year: 2019, Month: time.December}
+
+	if got, want := m.String(), "2019-12"; got != want {
+		t.Error("should format the month as YYYY-MM", cross, got)
+	}
+
+	if got, want := m.Next(), (Month{Year: 2020, Month: time.January}); got != want {
+		t.Error("should roll over to the next year", cross, got)
+	}
+
+	if got, want := m.Next().Prev(), m; got != want {
+		t.Error("should roll back to the previous year", cross, got)
+	}
+
+	if got, want := (Month{Year: 2020, Month: time.February}).Days(), 29; got != want {
+		t.Error("should return the number of days in a leap month", cross, got)
+	}
+}
+
+func TestMonthOverMonth(t *testing.T) {
+	txns := append([]Txn{
+		{UID: "7", Time: day(2019, 4, 1), Direction: Out, Amount: gbp(1000)},
+	}, groupTxns...)
+
+	got := MonthOverMonth(txns, "GBP")
+
+	if len(got) != 4 {
+		t.Fatal("should include every month between the first and last transaction", cross, len(got))
+	}
+
+	if got[0].Spent != gbp(6299) || got[0].Delta != gbp(0) {
+		t.Error("should not report a change for the first month", cross, got[0])
+	}
+
+	if got[1].Spent != gbp(1999) || got[1].Delta != gbp(-4300) {
+		t.Error("should report the change from the previous month", cross, got[1])
+	}
+
+	if got[2].Spent != gbp(0) || got[2].Percent != -100 {
+		t.Error("should include months without transactions", cross, got[2])
+	}
+
+	if got[3].Delta != gbp(1000) || got[3].Percent != 0 {
+		t.Error("should not report a percentage change from zero", cross, got[3])
+	}
+
+	if MonthOverMonth(txns, "USD") != nil {
+		t.Error("should return nil when there are no transactions in the currency", cross)
+	}
+}
+
+func TestMonthlyReport(t *testing.T) {
+	r := MonthlyReport(groupTxns, Month{Year: 2019, Month: time.February}, "GBP", 5)
+
+	if r.Count != 1 || r.Spent != gbp(1999) {
+		t.Error("should only include transactions in the month and currency", cross, r.Count, r.Spent)
+	}
+
+	if len(r.Categories) != 1 {
+		t.Fatal("should include each category spent in during the month", cross, len(r.Categories))
+	}
+
+	c := r.Categories[0]
+	if c.Key != "GROCERIES" || c.Previous != gbp(5499) || c.Delta != gbp(-3500) {
+		t.Error("should compare each category to the previous month", cross, c)
+	}
+
+	if r.AverageDailySpend != gbp(71) {
+		t.Error("should calculate the average daily spend", cross, r.AverageDailySpend)
+	}
+
+	if len(r.TopMerchants) != 1 || r.TopMerchants[0].Key != "grocer" {
+		t.Error("should include the top merchants", cross, r.TopMerchants)
+	}
+}
@@ -0,0 +1,155 @@
+package insights
+
+import (
+	"time"
+
+	"github.com/billglover/starling"
+	"github.com/pkg/errors"
+)
+
+// Direction indicates whether money is flowing into or out of an account
+type Direction string
+
+// Directions used when normalising transactions
+const (
+	In  Direction = "IN"
+	Out Direction = "OUT"
+)
+
+// Txn is a normalised view of a transaction. The Amount is always held as a positive
+// value in minor units, with the Direction indicating the flow of money.
+type Txn struct {
+	UID          string
+	Time         time.Time
+	Direction    Direction
+	Amount       starling.Amount
+	Category     string // Spending category, e.g. GROCERIES
+	MerchantUID  string // Empty if the counter-party is not a merchant
+	CounterParty string // Counter-party UID where known, otherwise the narrative
+	Source       string
+	Status       string // Empty if the source does not report a status
+}
+
+// internalTransfer is the source of feed items that move money between an account and
+// its savings goals
+const internalTransfer = "INTERNAL_TRANSFER"
+
+// FromFeed converts feed Items into a slice of Txn. Items that have not moved money, see
+// starling.Item.Settled, and transfers to and from savings goals are excluded.
+func FromFeed(items []starling.Item) []Txn {
+	txns := []Txn{}
+	for _, itm := range items {
+		if !itm.Settled() || itm.Source == internalTransfer {
+			continue
+		}
+
+		t := Txn{
+			UID:          itm.FeedItemUID,
+			Time:         itm.TransactionTime,
+			Direction:    Out,
+			Amount:       starling.Amount{Currency: itm.Amount.Currency, MinorUnits: abs(itm.Amount.MinorUnits)},
+			Category:     itm.SpendingCategory,
+			CounterParty: itm.CounterPartyUID,
+			Source:       itm.Source,
+			Status:       itm.Status,
+		}
+		if itm.Direction == "IN" {
+			t.Direction = In
+		}
+		if itm.CounterPartyType == "MERCHANT" {
+			t.MerchantUID = itm.CounterPartyUID
+		}
+		txns = append(txns, t)
+	}
+	return txns
+}
+
+// FromTransactions converts transaction summaries into a slice of Txn. Transaction
+// summaries carry no spending category or merchant details. An error is returned if
+// the creation time of a transaction cannot be parsed.
+func FromTransactions(ts []starling.Transaction) ([]Txn, error) {
+	txns := make([]Txn, len(ts))
+	for i, v := range ts {
+		t, err := fromTransaction(v)
+		if err != nil {
+			return nil, err
+		}
+		txns[i] = t
+	}
+	return txns, nil
+}
+
+// FromMastercard converts Mastercard transactions into a slice of Txn. An error is
+// returned if the creation time of a transaction cannot be parsed.
+func FromMastercard(ts []starling.MastercardTransaction) ([]Txn, error) {
+	txns := make([]Txn, len(ts))
+	for i, v := range ts {
+		t, err := fromTransaction(v.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		t.Category = v.SpendingCategory
+		t.MerchantUID = v.MerchantUID
+		t.Status = v.Status
+		if v.MerchantUID != "" {
+			t.CounterParty = v.MerchantUID
+		}
+		txns[i] = t
+	}
+	return txns, nil
+}
+
+// FromDirectDebits converts direct debit transactions into a slice of Txn. The mandate
+// UID is used to identify the counter-party. An error is returned if the creation time
+// of a transaction cannot be parsed.
+func FromDirectDebits(ts []starling.DDTransaction) ([]Txn, error) {
+	txns := make([]Txn, len(ts))
+	for i, v := range ts {
+		t, err := fromTransaction(starling.Transaction{
+			UID:       v.UID,
+			Currency:  v.Currency,
+			Amount:    v.Amount,
+			Direction: v.Direction,
+			Created:   v.Created,
+			Narrative: v.Narrative,
+			Source:    v.Source,
+		})
+		if err != nil {
+			return nil, err
+		}
+		t.Category = v.SpendingCategory
+		t.MerchantUID = v.MerchantUID
+		if v.MandateUID != "" {
+			t.CounterParty = v.MandateUID
+		}
+		txns[i] = t
+	}
+	return txns, nil
+}
+
+func fromTransaction(v starling.Transaction) (Txn, error) {
+	created, err := time.Parse(time.RFC3339Nano, v.Created)
+	if err != nil {
+		return Txn{}, errors.Wrap(err, "unable to parse transaction time for "+v.UID)
+	}
+
+	t := Txn{
+		UID:          v.UID,
+		Time:         created,
+		Direction:    Out,
+		Amount:       starling.Amount{Currency: v.Currency, MinorUnits: starling.MinorUnits(v.Amount, v.Currency)},
+		CounterParty: v.Narrative,
+		Source:       v.Source,
+	}
+	if v.Direction == "INBOUND" || (v.Direction == "" && v.Amount > 0) {
+		t.Direction = In
+	}
+	return t, nil
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,162 @@
+package insights
+
+import (
+	"testing"
+	"time"
+
+	"github.com/billglover/starling"
+)
+
+const (
+	tick  = "\u2713"
+	cross = "\u2717"
+)
+
+func TestFromFeed(t *testing.T) {
+	items := []starling.Item{
+		{
+			FeedItemUID:      "dbb59f1c-39e6-4558-87ba-11c142965393",
+			Amount:           starling.Amount{Currency: "GBP", MinorUnits: 320},
+			Direction:        "OUT",
+			TransactionTime:  time.Date(2018, 6, 28, 7, 16, 28, 0, time.UTC),
+			Source:           "MASTER_CARD",
+			CounterPartyType: "MERCHANT",
+			CounterPartyUID:  "e6dbe57e-7c23-4015-97a4-4afbbf7faa23",
+			SpendingCategory: "HOLIDAYS",
+			Status:           "SETTLED",
+		},
+		{
+			FeedItemUID:      "a7b1c3f6-36b8-4a0e-9f0e-1e5dd7a0bdaa",
+			Amount:           starling.Amount{Currency: "GBP", MinorUnits: 1000},
+			Direction:        "IN",
+			TransactionTime:  time.Date(2018, 6, 29, 9, 0, 0, 0, time.UTC),
+			Source:           "FASTER_PAYMENTS_IN",
+			CounterPartyType: "PAYEE",
+			CounterPartyUID:  "70da9d4f-bd3a-4dd2-9bb8-a2b1d2a0a1a9",
+			SpendingCategory: "INCOME",
+			Status:           "SETTLED",
+		},
+	}
+
+	got := FromFeed(items)
+	if len(got) != len(items) {
+		t.Fatal("should return a Txn for each feed item", cross, len(got))
+	}
+
+	if got[0].Direction != Out || got[1].Direction != In {
+		t.Error("should map the feed direction", cross, got[0].Direction, got[1].Direction)
+	}
+
+	if got[0].MerchantUID != items[0].CounterPartyUID {
+		t.Error("should use the counter-party as the merchant for merchant transactions", cross)
+	}
+
+	if got[1].MerchantUID != "" {
+		t.Error("should not set a merchant for non-merchant transactions", cross, got[1].MerchantUID)
+	}
+
+	if got[0].Amount.MinorUnits != 320 || got[0].Category != "HOLIDAYS" {
+		t.Error("should copy the amount and spending category", cross, got[0])
+	}
+	if got[0].Status != "SETTLED" {
+		t.Error("should copy the status", cross, got[0].Status)
+	}
+}
+
+func TestFromFeedExcludesUnsettled(t *testing.T) {
+	items := []starling.Item{}
+	for _, s := range []string{"UPCOMING", "DECLINED", "REVERSED", "ACCOUNT_CHECK", "SETTLED", "PENDING"} {
+		items = append(items, starling.Item{
+			FeedItemUID: s,
+			Amount:      starling.Amount{Currency: "GBP", MinorUnits: 500},
+			Direction:   "OUT",
+			Source:      "MASTER_CARD",
+			Status:      s,
+		})
+	}
+	items = append(items, starling.Item{
+		FeedItemUID: "transfer",
+		Amount:      starling.Amount{Currency: "GBP", MinorUnits: 500},
+		Direction:   "OUT",
+		Source:      "INTERNAL_TRANSFER",
+		Status:      "SETTLED",
+	})
+
+	got := FromFeed(items)
+	if len(got) != 2 || got[0].UID != "SETTLED" || got[1].UID != "PENDING" {
+		t.Error("should only include items that have moved money outside of savings goals", cross, got)
+	}
+}
+
+func TestFromMastercard(t *testing.T) {
+	mc := []starling.MastercardTransaction{
+		{
+			Transaction: starling.Transaction{
+				UID:       "6d62b4e1-61ef-4b4c-b6f0-b6eb0d1b4a4b",
+				Currency:  "GBP",
+				Amount:    -23.45,
+				Direction: "OUTBOUND",
+				Created:   "2017-07-05T18:27:02.335Z",
+				Narrative: "Borough Barista",
+			},
+			MerchantUID:      "b6c146f7-666e-4868-beed-21344b7e6e47",
+			SpendingCategory: "EATING_OUT",
+		},
+	}
+
+	got, err := FromMastercard(mc)
+	if err != nil {
+		t.Fatal("should convert without error", cross, err)
+	}
+
+	if got[0].Amount.MinorUnits != 2345 {
+		t.Error("should convert the amount to positive minor units", cross, got[0].Amount.MinorUnits)
+	}
+
+	if got[0].Direction != Out {
+		t.Error("should map the direction", cross, got[0].Direction)
+	}
+
+	if got[0].CounterParty != mc[0].MerchantUID || got[0].MerchantUID != mc[0].MerchantUID {
+		t.Error("should use the merchant as the counter-party", cross, got[0].CounterParty)
+	}
+
+	if want := time.Date(2017, 7, 5, 18, 27, 2, 335000000, time.UTC); !got[0].Time.Equal(want) {
+		t.Error("should parse the creation time", cross, got[0].Time)
+	}
+}
+
+func TestFromDirectDebits(t *testing.T) {
+	dd := []starling.DDTransaction{
+		{
+			UID:              "b8e3a7b1-6a7d-4f1c-9a1a-5b0b5d0b4c5e",
+			Currency:         "GBP",
+			Amount:           -42.13,
+			Direction:        "OUTBOUND",
+			Created:          "2018-04-16T23:30:00.000Z",
+			Narrative:        "Society of Antiquaries",
+			MandateUID:       "7bd1bd67-0d6a-4bcf-b9e1-8c9f5b5a2b1d",
+			SpendingCategory: "GENERAL",
+		},
+	}
+
+	got, err := FromDirectDebits(dd)
+	if err != nil {
+		t.Fatal("should convert without error", cross, err)
+	}
+
+	if got[0].CounterParty != dd[0].MandateUID {
+		t.Error("should use the mandate as the counter-party", cross, got[0].CounterParty)
+	}
+
+	if got[0].Amount.MinorUnits != 4213 || got[0].Category != "GENERAL" {
+		t.Error("should copy the amount and spending category", cross, got[0])
+	}
+}
+
+func TestFromTransactionsInvalidTime(t *testing.T) {
+	_, err := FromTransactions([]starling.Transaction{{UID: "1", Created: "yesterday"}})
+	if err == nil {
+		t.Error("should return an error when unable to parse the creation time", cross)
+	}
+}
@@ -1,6 +1,7 @@
 package starling
 
 import (
+	"math"
 	"strings"
 	"time"
 )
@@ -23,6 +24,29 @@ type Amount struct {
 	MinorUnits int64  `json:"minorUnits"` // Amount in the minor units of the given currency; eg pence in GBP, cents in EUR
 }
 
+// MinorUnits converts a decimal amount, as returned by the v1 API, into a positive number
+// of minor units for the given currency.
+func MinorUnits(v float64, currency string) int64 {
+	return int64(math.Round(math.Abs(v) * math.Pow10(CurrencyExponent(currency))))
+}
+
+// CurrencyExponent returns the number of decimal places of minor units in the currency,
+// e.g. 2 for GBP and 0 for JPY.
+func CurrencyExponent(currency string) int {
+	if e, ok := exponents[currency]; ok {
+		return e
+	}
+	return 2
+}
+
+// exponents lists the currencies that do not have two decimal places of minor units
+var exponents = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"BHD": 3,
+	"KWD": 3,
+}
+
 // RecurrenceRule defines the pattern for recurring events
 type RecurrenceRule struct {
 	StartDate string `json:"startDate"`
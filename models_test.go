@@ -0,0 +1,22 @@
+package starling
+
+import "testing"
+
+func TestMinorUnits(t *testing.T) {
+	tcs := []struct {
+		v        float64
+		currency string
+		want     int64
+	}{
+		{v: 13.99, currency: "GBP", want: 1399},
+		{v: -0.29, currency: "EUR", want: 29},
+		{v: 1000, currency: "JPY", want: 1000},
+		{v: 1.005, currency: "KWD", want: 1005},
+	}
+
+	for _, tc := range tcs {
+		if got := MinorUnits(tc.v, tc.currency); got != tc.want {
+			t.Errorf("should convert %v %s to %d minor units %s %d", tc.v, tc.currency, tc.want, cross, got)
+		}
+	}
+}
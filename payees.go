@@ -0,0 +1,345 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/billglover/starling/validation"
+	"github.com/pkg/errors"
+)
+
+// PayeeType describes whether a payee is a person or a business
+type PayeeType string
+
+// Types of payee
+const (
+	PayeeIndividual PayeeType = "INDIVIDUAL"
+	PayeeBusiness   PayeeType = "BUSINESS"
+)
+
+// BankIdentifierType describes how the bank of a payee account is identified
+type BankIdentifierType string
+
+// Types of bank identifier
+const (
+	BankIdentifierSortCode BankIdentifierType = "SORT_CODE"
+	BankIdentifierSWIFT    BankIdentifierType = "SWIFT"
+	BankIdentifierIBAN     BankIdentifierType = "IBAN"
+	BankIdentifierABA      BankIdentifierType = "ABA"
+)
+
+// PayeeAccount is an account belonging to a payee. For a UK account the bank identifier
+// is the sort code and the account identifier is the account number. For an
+// international account the bank identifier is the BIC and the account identifier is the
+// IBAN.
+type PayeeAccount struct {
+	UID                string             `json:"payeeAccountUid,omitempty"`
+	ChannelType        string             `json:"payeeChannelType,omitempty"`
+	Description        string             `json:"description"`
+	DefaultAccount     bool               `json:"defaultAccount"`
+	CountryCode        string             `json:"countryCode"`
+	AccountIdentifier  string             `json:"accountIdentifier"`
+	BankIdentifier     string             `json:"bankIdentifier"`
+	BankIdentifierType BankIdentifierType `json:"bankIdentifierType"`
+}
+
+// Validate checks the identifiers of the account. The BIC and IBAN of an international
+// account are checked using the validation package. The sort code and account number of a
+// UK account are checked using the validator, unless it is nil.
+func (pa PayeeAccount) Validate(v AccountValidator) error {
+	switch pa.BankIdentifierType {
+	case BankIdentifierSortCode:
+		if v == nil {
+			return nil
+		}
+		return v.Check(pa.BankIdentifier, pa.AccountIdentifier)
+	case BankIdentifierSWIFT:
+		if err := validation.CheckBIC(pa.BankIdentifier); err != nil {
+			return err
+		}
+		if validation.UsesIBAN(pa.CountryCode) {
+			return validation.CheckIBAN(pa.AccountIdentifier)
+		}
+	case BankIdentifierIBAN:
+		return validation.CheckIBAN(pa.AccountIdentifier)
+	}
+	return nil
+}
+
+// Payee is someone to whom payments can be made
+type Payee struct {
+	UID          string         `json:"payeeUid,omitempty"`
+	Name         string         `json:"payeeName"`
+	PhoneNumber  string         `json:"phoneNumber,omitempty"`
+	Type         PayeeType      `json:"payeeType"`
+	FirstName    string         `json:"firstName,omitempty"`
+	MiddleName   string         `json:"middleName,omitempty"`
+	LastName     string         `json:"lastName,omitempty"`
+	BusinessName string         `json:"businessName,omitempty"`
+	DateOfBirth  string         `json:"dateOfBirth,omitempty"`
+	Accounts     []PayeeAccount `json:"accounts,omitempty"`
+}
+
+type payees struct {
+	Payees []Payee `json:"payees"`
+}
+
+type payeeResponse struct {
+	UID     string        `json:"payeeUid"`
+	Success bool          `json:"success"`
+	Errors  []ErrorDetail `json:"errors"`
+}
+
+type payeeAccountResponse struct {
+	UID     string        `json:"payeeAccountUid"`
+	Success bool          `json:"success"`
+	Errors  []ErrorDetail `json:"errors"`
+}
+
+// PayeeScheduledPayment is a payment scheduled to be made to a payee account
+type PayeeScheduledPayment struct {
+	UID              string         `json:"paymentOrderUid"`
+	CategoryUID      string         `json:"categoryUid"`
+	Amount           Amount         `json:"amount"`
+	Reference        string         `json:"reference"`
+	PayeeUID         string         `json:"payeeUid"`
+	PayeeAccountUID  string         `json:"payeeAccountUid"`
+	RecurrenceRule   RecurrenceRule `json:"recurrenceRule"`
+	StartDate        string         `json:"startDate"`
+	NextPaymentDate  string         `json:"nextPaymentDate"`
+	EndDate          string         `json:"endDate"`
+	PaymentType      string         `json:"paymentType"`
+	SpendingCategory string         `json:"spendingCategory"`
+}
+
+// PayeePayment is a payment made to a payee account
+type PayeePayment struct {
+	UID             string    `json:"paymentUid"`
+	Amount          Amount    `json:"amount"`
+	Reference       string    `json:"reference"`
+	PayeeUID        string    `json:"payeeUid"`
+	PayeeAccountUID string    `json:"payeeAccountUid"`
+	CreatedAt       time.Time `json:"createdAt"`
+	CompletedAt     time.Time `json:"completedAt"`
+	RejectedAt      time.Time `json:"rejectedAt"`
+	PaymentStatus   string    `json:"paymentStatus"`
+}
+
+// Payees returns the payees for the current customer along with their accounts.
+//
+// Note: Payees uses the v2 API which is still under active development.
+func (c *Client) Payees(ctx context.Context) ([]Payee, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/payees", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var p payees
+	resp, err := c.Do(ctx, req, &p)
+	if err != nil {
+		return nil, resp, err
+	}
+	return p.Payees, resp, nil
+}
+
+// CreatePayee creates a payee with one or more accounts and returns the UID of the payee.
+// An error is returned without calling the API if an account fails validation using
+// PayeeAccount.Validate, or if the API reports that the payee could not be created.
+//
+// Note: CreatePayee uses the v2 API which is still under active development.
+func (c *Client) CreatePayee(ctx context.Context, p Payee) (string, *http.Response, error) {
+	if err := c.validatePayeeAccounts(p.Accounts...); err != nil {
+		return "", nil, err
+	}
+
+	req, err := c.NewRequest("PUT", "/api/v2/payees", p)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return c.doPayee(ctx, req)
+}
+
+// UpdatePayee updates the details of a payee. Accounts are managed separately using
+// CreatePayeeAccount, UpdatePayeeAccount and DeletePayeeAccount. An error is returned if
+// the API reports that the payee could not be updated.
+//
+// Note: UpdatePayee uses the v2 API which is still under active development.
+func (c *Client) UpdatePayee(ctx context.Context, uid string, p Payee) (*http.Response, error) {
+	p.UID, p.Accounts = "", nil
+
+	req, err := c.NewRequest("PUT", "/api/v2/payees/"+uid, p)
+	if err != nil {
+		return nil, err
+	}
+
+	_, resp, err := c.doPayee(ctx, req)
+	return resp, err
+}
+
+// doPayee sends a request to create or update a payee and checks the result.
+func (c *Client) doPayee(ctx context.Context, req *http.Request) (string, *http.Response, error) {
+	var pr *payeeResponse
+	resp, err := c.Do(ctx, req, &pr)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if pr == nil {
+		return "", resp, errors.New("no payee response returned")
+	}
+
+	if !pr.Success {
+		return pr.UID, resp, detailErrors(pr.Errors)
+	}
+	return pr.UID, resp, nil
+}
+
+// DeletePayee deletes a payee and all of its accounts.
+//
+// Note: DeletePayee uses the v2 API which is still under active development.
+func (c *Client) DeletePayee(ctx context.Context, uid string) (*http.Response, error) {
+	req, err := c.NewRequest("DELETE", "/api/v2/payees/"+uid, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, nil)
+}
+
+// CreatePayeeAccount adds an account to a payee and returns the UID of the account. An
+// error is returned without calling the API if the account fails validation using
+// PayeeAccount.Validate, or if the API reports that the account could not be created.
+//
+// Note: CreatePayeeAccount uses the v2 API which is still under active development.
+func (c *Client) CreatePayeeAccount(ctx context.Context, payeeUID string, pa PayeeAccount) (string, *http.Response, error) {
+	if err := c.validatePayeeAccounts(pa); err != nil {
+		return "", nil, err
+	}
+
+	req, err := c.NewRequest("PUT", "/api/v2/payees/"+payeeUID+"/account", pa)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return c.doPayeeAccount(ctx, req)
+}
+
+// UpdatePayeeAccount updates an account belonging to a payee. An error is returned without
+// calling the API if the account fails validation using PayeeAccount.Validate, or if the
+// API reports that the account could not be updated.
+//
+// Note: UpdatePayeeAccount uses the v2 API which is still under active development.
+func (c *Client) UpdatePayeeAccount(ctx context.Context, payeeUID, accountUID string, pa PayeeAccount) (*http.Response, error) {
+	if err := c.validatePayeeAccounts(pa); err != nil {
+		return nil, err
+	}
+	pa.UID = ""
+
+	req, err := c.NewRequest("PUT", "/api/v2/payees/"+payeeUID+"/account/"+accountUID, pa)
+	if err != nil {
+		return nil, err
+	}
+
+	_, resp, err := c.doPayeeAccount(ctx, req)
+	return resp, err
+}
+
+// doPayeeAccount sends a request to create or update a payee account and checks the
+// result.
+func (c *Client) doPayeeAccount(ctx context.Context, req *http.Request) (string, *http.Response, error) {
+	var par *payeeAccountResponse
+	resp, err := c.Do(ctx, req, &par)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if par == nil {
+		return "", resp, errors.New("no payee account response returned")
+	}
+
+	if !par.Success {
+		return par.UID, resp, detailErrors(par.Errors)
+	}
+	return par.UID, resp, nil
+}
+
+// validatePayeeAccounts checks the accounts using the AccountValidator of the client.
+func (c *Client) validatePayeeAccounts(pas ...PayeeAccount) error {
+	for _, pa := range pas {
+		if err := pa.Validate(c.validator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeletePayeeAccount deletes an account belonging to a payee.
+//
+// Note: DeletePayeeAccount uses the v2 API which is still under active development.
+func (c *Client) DeletePayeeAccount(ctx context.Context, payeeUID, accountUID string) (*http.Response, error) {
+	req, err := c.NewRequest("DELETE", "/api/v2/payees/"+payeeUID+"/account/"+accountUID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, nil)
+}
+
+// PayeeImage returns the image associated with a payee. The image can be decoded using
+// Photo.Image.
+//
+// Note: PayeeImage uses the v2 API which is still under active development.
+func (c *Client) PayeeImage(ctx context.Context, uid string) (*Photo, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/payees/"+uid+"/image", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var photo *Photo
+	resp, err := c.Do(ctx, req, &photo)
+	return photo, resp, err
+}
+
+// PayeeScheduledPayments returns the payments scheduled to be made to a payee account.
+//
+// Note: PayeeScheduledPayments uses the v2 API which is still under active development.
+func (c *Client) PayeeScheduledPayments(ctx context.Context, payeeUID, accountUID string) ([]PayeeScheduledPayment, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/payees/"+payeeUID+"/account/"+accountUID+"/scheduled-payments", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sp struct {
+		Payments []PayeeScheduledPayment `json:"scheduledPayments"`
+	}
+	resp, err := c.Do(ctx, req, &sp)
+	if err != nil {
+		return nil, resp, err
+	}
+	return sp.Payments, resp, nil
+}
+
+// PayeePayments returns the payments made to a payee account since the given date.
+//
+// Note: PayeePayments uses the v2 API which is still under active development.
+func (c *Client) PayeePayments(ctx context.Context, payeeUID, accountUID string, since time.Time) ([]PayeePayment, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/payees/"+payeeUID+"/account/"+accountUID+"/payments", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("since", since.Format(dateLayout))
+	req.URL.RawQuery = q.Encode()
+
+	var p struct {
+		Payments []PayeePayment `json:"payments"`
+	}
+	resp, err := c.Do(ctx, req, &p)
+	if err != nil {
+		return nil, resp, err
+	}
+	return p.Payments, resp, nil
+}
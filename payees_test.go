@@ -0,0 +1,395 @@
+package starling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/billglover/starling/validation"
+)
+
+const payeesMock = `{
+	"payees": [
+		{
+			"payeeUid": "840e4030-b94c-4e71-a1d3-1319a233dd3c",
+			"payeeName": "Jane Smith",
+			"phoneNumber": "07700900000",
+			"payeeType": "INDIVIDUAL",
+			"firstName": "Jane",
+			"lastName": "Smith",
+			"accounts": [
+				{
+					"payeeAccountUid": "9d8a5a5c-4b6f-4f8e-8a2f-7b5c1d3e2f10",
+					"payeeChannelType": "BANK_ACCOUNT",
+					"description": "Current account",
+					"defaultAccount": true,
+					"countryCode": "GB",
+					"accountIdentifier": "12345678",
+					"bankIdentifier": "608371",
+					"bankIdentifierType": "SORT_CODE"
+				},
+				{
+					"payeeAccountUid": "2c4f6e8a-1b3d-4f5a-9c7e-0d2b4f6a8c1e",
+					"payeeChannelType": "BANK_ACCOUNT",
+					"description": "Euro account",
+					"countryCode": "DE",
+					"accountIdentifier": "DE89370400440532013000",
+					"bankIdentifier": "COBADEFFXXX",
+					"bankIdentifierType": "SWIFT"
+				}
+			]
+		}
+	]
+}`
+
+// TestPayees confirms that the client is able to list payees along with their accounts.
+func TestPayees(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/payees", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprint(w, payeesMock)
+	})
+
+	got, _, err := client.Payees(context.Background())
+	checkNoError(t, err)
+
+	want := &payees{}
+	json.Unmarshal([]byte(payeesMock), want)
+
+	if !reflect.DeepEqual(got, want.Payees) {
+		t.Error("should return payees matching the mock response", cross)
+	}
+
+	if len(got) != 1 || len(got[0].Accounts) != 2 {
+		t.Fatal("should return the accounts of each payee", cross, got)
+	}
+
+	if got[0].Accounts[1].BankIdentifierType != BankIdentifierSWIFT {
+		t.Error("should return the bank identifier type of each account", cross, got[0].Accounts[1])
+	}
+}
+
+// TestCreatePayee confirms that the client is able to create a payee with its accounts.
+func TestCreatePayee(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	want := Payee{
+		Name:      "Jane Smith",
+		Type:      PayeeIndividual,
+		FirstName: "Jane",
+		LastName:  "Smith",
+		Accounts: []PayeeAccount{
+			{Description: "Current account", DefaultAccount: true, CountryCode: "GB", AccountIdentifier: "12345678", BankIdentifier: "608371", BankIdentifierType: BankIdentifierSortCode},
+		},
+	}
+
+	mux.HandleFunc("/api/v2/payees", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+
+		var got Payee
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal("should send a request that the API can parse", cross, err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Error("should send a payee that matches the mock", cross, got)
+		}
+
+		fmt.Fprint(w, `{"payeeUid": "840e4030-b94c-4e71-a1d3-1319a233dd3c", "success": true, "errors": []}`)
+	})
+
+	uid, _, err := client.CreatePayee(context.Background(), want)
+	checkNoError(t, err)
+
+	if uid != "840e4030-b94c-4e71-a1d3-1319a233dd3c" {
+		t.Error("should return the UID of the payee", cross, uid)
+	}
+}
+
+// TestCreatePayee_Unsuccessful confirms that the client returns an error when the API
+// reports that a payee could not be created.
+func TestCreatePayee_Unsuccessful(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/payees", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"success": false, "errors": [{"message": "INVALID_SORT_CODE"}]}`)
+	})
+
+	_, _, err := client.CreatePayee(context.Background(), Payee{Name: "Jane Smith"})
+	checkHasError(t, err)
+
+	if err.Error() != "INVALID_SORT_CODE" {
+		t.Error("should return the errors reported by the API", cross, err)
+	}
+}
+
+// TestUpdatePayee confirms that the client sends the payee details without its accounts.
+func TestUpdatePayee(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	uid := "840e4030-b94c-4e71-a1d3-1319a233dd3c"
+
+	mux.HandleFunc("/api/v2/payees/"+uid, func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+
+		var got map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&got)
+
+		if got["payeeName"] != "Jane Jones" {
+			t.Error("should send the updated name", cross, got["payeeName"])
+		}
+
+		if _, ok := got["accounts"]; ok {
+			t.Error("should not send the accounts of the payee", cross, got["accounts"])
+		}
+
+		fmt.Fprintf(w, `{"payeeUid": "%s", "success": true, "errors": []}`, uid)
+	})
+
+	p := Payee{UID: uid, Name: "Jane Jones", Type: PayeeIndividual, Accounts: []PayeeAccount{{Description: "Current account"}}}
+	resp, err := client.UpdatePayee(context.Background(), uid, p)
+	checkNoError(t, err)
+	checkStatus(t, resp, http.StatusOK)
+}
+
+// TestDeletePayee confirms that the client is able to delete a payee.
+func TestDeletePayee(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	uid := "840e4030-b94c-4e71-a1d3-1319a233dd3c"
+
+	mux.HandleFunc("/api/v2/payees/"+uid, func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.DeletePayee(context.Background(), uid)
+	checkNoError(t, err)
+	checkStatus(t, resp, http.StatusNoContent)
+}
+
+// TestPayeeAccounts confirms that the client is able to create, update and delete the
+// accounts belonging to a payee.
+func TestPayeeAccounts(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	payee := "840e4030-b94c-4e71-a1d3-1319a233dd3c"
+	account := "2c4f6e8a-1b3d-4f5a-9c7e-0d2b4f6a8c1e"
+	pa := PayeeAccount{Description: "Euro account", CountryCode: "DE", AccountIdentifier: "DE89370400440532013000", BankIdentifier: "COBADEFFXXX", BankIdentifierType: BankIdentifierSWIFT}
+
+	mux.HandleFunc("/api/v2/payees/"+payee+"/account", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+
+		var got PayeeAccount
+		json.NewDecoder(r.Body).Decode(&got)
+		if got != pa {
+			t.Error("should send the account to create", cross, got)
+		}
+
+		fmt.Fprintf(w, `{"payeeAccountUid": "%s", "success": true, "errors": []}`, account)
+	})
+
+	mux.HandleFunc("/api/v2/payees/"+payee+"/account/"+account, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			var got PayeeAccount
+			json.NewDecoder(r.Body).Decode(&got)
+			if got.Description != "Savings" || got.UID != "" {
+				t.Error("should send the account to update", cross, got)
+			}
+			fmt.Fprintf(w, `{"payeeAccountUid": "%s", "success": true, "errors": []}`, account)
+		case "DELETE":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Error("should update or delete the account", cross, r.Method)
+		}
+	})
+
+	uid, _, err := client.CreatePayeeAccount(context.Background(), payee, pa)
+	checkNoError(t, err)
+	if uid != account {
+		t.Error("should return the UID of the account", cross, uid)
+	}
+
+	pa.UID, pa.Description = account, "Savings"
+	_, err = client.UpdatePayeeAccount(context.Background(), payee, account, pa)
+	checkNoError(t, err)
+
+	resp, err := client.DeletePayeeAccount(context.Background(), payee, account)
+	checkNoError(t, err)
+	checkStatus(t, resp, http.StatusNoContent)
+}
+
+// TestPayeeImage confirms that the client is able to retrieve the image for a payee.
+func TestPayeeImage(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	uid := "840e4030-b94c-4e71-a1d3-1319a233dd3c"
+
+	mux.HandleFunc("/api/v2/payees/"+uid+"/image", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprint(w, `{"base64EncodedPhoto": "aGVsbG8="}`)
+	})
+
+	got, _, err := client.PayeeImage(context.Background(), uid)
+	checkNoError(t, err)
+
+	if got == nil || got.Base64EncodedPhoto != "aGVsbG8=" {
+		t.Error("should return the image matching the mock response", cross, got)
+	}
+}
+
+// TestPayeeScheduledPayments confirms that the client is able to list the payments
+// scheduled for a payee account.
+func TestPayeeScheduledPayments(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	payee := "840e4030-b94c-4e71-a1d3-1319a233dd3c"
+	account := "9d8a5a5c-4b6f-4f8e-8a2f-7b5c1d3e2f10"
+
+	mux.HandleFunc("/api/v2/payees/"+payee+"/account/"+account+"/scheduled-payments", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprint(w, `{"scheduledPayments": [{
+			"paymentOrderUid": "1e22a383-0dd6-4845-a5fd-17c55920381d",
+			"amount": {"currency": "GBP", "minorUnits": 5000},
+			"reference": "Rent",
+			"recurrenceRule": {"startDate": "2019-03-01", "frequency": "MONTHLY"},
+			"nextPaymentDate": "2019-04-01"
+		}]}`)
+	})
+
+	got, _, err := client.PayeeScheduledPayments(context.Background(), payee, account)
+	checkNoError(t, err)
+
+	if len(got) != 1 || got[0].Amount.MinorUnits != 5000 || got[0].RecurrenceRule.Frequency != FrequencyMonthly {
+		t.Error("should return the scheduled payments matching the mock response", cross, got)
+	}
+}
+
+// TestPayeePayments confirms that the client is able to list the payments made to a payee
+// account since a given date.
+func TestPayeePayments(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	payee := "840e4030-b94c-4e71-a1d3-1319a233dd3c"
+	account := "9d8a5a5c-4b6f-4f8e-8a2f-7b5c1d3e2f10"
+
+	mux.HandleFunc("/api/v2/payees/"+payee+"/account/"+account+"/payments", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+
+		if got := r.URL.Query().Get("since"); got != "2019-01-01" {
+			t.Error("should request payments since the given date", cross, got)
+		}
+
+		fmt.Fprint(w, `{"payments": [{
+			"paymentUid": "5c0f2d1e-3a4b-4c5d-8e9f-0a1b2c3d4e5f",
+			"amount": {"currency": "GBP", "minorUnits": 5000},
+			"reference": "Rent",
+			"createdAt": "2019-03-01T09:00:00.000Z",
+			"paymentStatus": "ACCEPTED"
+		}]}`)
+	})
+
+	got, _, err := client.PayeePayments(context.Background(), payee, account, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+	checkNoError(t, err)
+
+	if len(got) != 1 || got[0].UID != "5c0f2d1e-3a4b-4c5d-8e9f-0a1b2c3d4e5f" || got[0].CreatedAt.IsZero() {
+		t.Error("should return the payments matching the mock response", cross, got)
+	}
+}
+
+// TestCreatePayee_InvalidAccount confirms that payee accounts identified by a sort code are
+// validated before they are sent to the API.
+func TestCreatePayee_InvalidAccount(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.validator = rejectAccount
+
+	mux.HandleFunc("/api/v2/payees", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not send an invalid account to the API", cross)
+	})
+
+	mux.HandleFunc("/api/v2/payees/840e4030-b94c-4e71-a1d3-1319a233dd3c/account", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"payeeAccountUid": "2c4f6e8a-1b3d-4f5a-9c7e-0d2b4f6a8c1e", "success": true, "errors": []}`)
+	})
+
+	invalid := PayeeAccount{CountryCode: "GB", AccountIdentifier: "00000000", BankIdentifier: "608371", BankIdentifierType: BankIdentifierSortCode}
+	p := Payee{Name: "Jane Smith", Type: PayeeIndividual, Accounts: []PayeeAccount{invalid}}
+
+	if _, _, err := client.CreatePayee(context.Background(), p); err != errInvalidAccount {
+		t.Error("should return the validation error", cross, err)
+	}
+
+	iban := PayeeAccount{CountryCode: "DE", AccountIdentifier: "DE89370400440532013000", BankIdentifier: "COBADEFFXXX", BankIdentifierType: BankIdentifierSWIFT}
+	_, _, err := client.CreatePayeeAccount(context.Background(), "840e4030-b94c-4e71-a1d3-1319a233dd3c", iban)
+	checkNoError(t, err)
+}
+
+var payeeAccountValidateTC = []struct {
+	name string
+	pa   PayeeAccount
+	want error
+}{
+	{
+		name: "valid sort code and account number",
+		pa:   PayeeAccount{CountryCode: "GB", AccountIdentifier: "12345678", BankIdentifier: "608371", BankIdentifierType: BankIdentifierSortCode},
+	},
+	{
+		name: "invalid account number",
+		pa:   PayeeAccount{CountryCode: "GB", AccountIdentifier: "00000000", BankIdentifier: "608371", BankIdentifierType: BankIdentifierSortCode},
+		want: errInvalidAccount,
+	},
+	{
+		name: "valid BIC and IBAN",
+		pa:   PayeeAccount{CountryCode: "DE", AccountIdentifier: "DE89 3704 0044 0532 0130 00", BankIdentifier: "COBADEFFXXX", BankIdentifierType: BankIdentifierSWIFT},
+	},
+	{
+		name: "invalid BIC",
+		pa:   PayeeAccount{CountryCode: "DE", AccountIdentifier: "DE89370400440532013000", BankIdentifier: "COBADEFF1", BankIdentifierType: BankIdentifierSWIFT},
+		want: validation.ErrInvalidBIC,
+	},
+	{
+		name: "invalid IBAN check digits",
+		pa:   PayeeAccount{CountryCode: "DE", AccountIdentifier: "DE88370400440532013000", BankIdentifier: "COBADEFFXXX", BankIdentifierType: BankIdentifierSWIFT},
+		want: validation.ErrIBANChecksum,
+	},
+	{
+		name: "account number in a country without IBANs",
+		pa:   PayeeAccount{CountryCode: "US", AccountIdentifier: "123456789", BankIdentifier: "CHASUS33", BankIdentifierType: BankIdentifierSWIFT},
+	},
+	{
+		name: "invalid IBAN length",
+		pa:   PayeeAccount{CountryCode: "NL", AccountIdentifier: "NL91ABNA041716430", BankIdentifierType: BankIdentifierIBAN},
+		want: validation.ErrIBANLength,
+	},
+}
+
+func TestPayeeAccountValidate(t *testing.T) {
+	for _, tc := range payeeAccountValidateTC {
+		t.Run(tc.name, func(st *testing.T) {
+			if got := tc.pa.Validate(rejectAccount); got != tc.want {
+				st.Error("should validate the account identifiers", cross, got)
+			}
+		})
+	}
+
+	pa := payeeAccountValidateTC[1].pa
+	if err := pa.Validate(nil); err != nil {
+		t.Error("should not check the sort code without a validator", cross, err)
+	}
+}
@@ -64,7 +64,12 @@ func (c *Client) MakeLocalPayment(ctx context.Context, p LocalPayment) (*http.Re
 }
 
 // CreateScheduledPayment creates a scheduled payment. It returns the UID for the scheduled payment.
+// An error is returned without calling the API if the schedule is not a valid RecurrenceRule.
 func (c *Client) CreateScheduledPayment(ctx context.Context, p ScheduledPayment) (string, *http.Response, error) {
+	if err := p.Schedule.Validate(); err != nil {
+		return "", nil, err
+	}
+
 	req, err := c.NewRequest("POST", "/api/v1/payments/scheduled", p)
 	if err != nil {
 		return "", nil, err
@@ -235,9 +235,9 @@ var paymentsCasesCreateScheduled = []struct {
 				},
 			},
 			Schedule: RecurrenceRule{
-				StartDate: "",
+				StartDate: "2017-09-23",
 				UntilDate: "",
-				Frequency: "",
+				Frequency: "MONTHLY",
 				Count:     2,
 				Interval:  2,
 				WeekStart: "MONDAY",
@@ -326,9 +326,9 @@ func TestCreateScheduledPaymentForbidden(t *testing.T) {
 			},
 		},
 		Schedule: RecurrenceRule{
-			StartDate: "",
+			StartDate: "2017-09-23",
 			UntilDate: "",
-			Frequency: "",
+			Frequency: "MONTHLY",
 			Count:     2,
 			Interval:  2,
 			WeekStart: "MONDAY",
@@ -0,0 +1,173 @@
+package starling
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"os"
+
+	// Register the formats accepted by ReadPhoto and Photo.Image.
+	_ "image/gif"
+	_ "image/png"
+
+	"github.com/pkg/errors"
+)
+
+// Limits applied to photos encoded for savings goals. Larger images are scaled down so
+// that neither side exceeds MaxPhotoDimension and re-encoded at a lower quality until the
+// encoded photo is no longer than MaxPhotoSize.
+const (
+	MaxPhotoDimension = 500
+	MaxPhotoSize      = 200 * 1024
+)
+
+// photoQualities are the JPEG qualities tried, in order, when encoding a photo
+var photoQualities = []int{90, 80, 70, 60, 50, 40}
+
+// ErrUnsupportedPhotoFormat is returned when a photo is not a JPEG, PNG or GIF image
+var ErrUnsupportedPhotoFormat = errors.New("unsupported photo format: must be JPEG, PNG or GIF")
+
+// ErrPhotoTooLarge is returned when a photo cannot be encoded within MaxPhotoSize
+var ErrPhotoTooLarge = errors.New("photo too large: unable to encode within the maximum size")
+
+// EncodePhoto scales the image to fit within MaxPhotoDimension and returns it as a base 64
+// encoded JPEG suitable for a SavingsGoalRequest. Transparency is not preserved. An error
+// is returned if the image cannot be encoded within MaxPhotoSize.
+func EncodePhoto(img image.Image) (string, error) {
+	img = fit(img, MaxPhotoDimension)
+
+	var buf bytes.Buffer
+	for _, q := range photoQualities {
+		buf.Reset()
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: q}); err != nil {
+			return "", errors.Wrap(err, "unable to encode photo")
+		}
+
+		if base64.StdEncoding.EncodedLen(buf.Len()) <= MaxPhotoSize {
+			return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+		}
+	}
+	return "", ErrPhotoTooLarge
+}
+
+// ReadPhoto decodes a JPEG, PNG or GIF image from r and encodes it using EncodePhoto.
+// ErrUnsupportedPhotoFormat is returned if the format of the image is not recognised.
+func ReadPhoto(r io.Reader) (string, error) {
+	img, _, err := image.Decode(r)
+	if err == image.ErrFormat {
+		return "", ErrUnsupportedPhotoFormat
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "unable to decode photo")
+	}
+	return EncodePhoto(img)
+}
+
+// PhotoFile reads the named image file and encodes it using EncodePhoto.
+func PhotoFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to open photo")
+	}
+	defer f.Close()
+
+	return ReadPhoto(f)
+}
+
+// SetPhoto encodes the image using EncodePhoto and sets it as the photo of the savings
+// goal.
+func (r *SavingsGoalRequest) SetPhoto(img image.Image) error {
+	p, err := EncodePhoto(img)
+	if err != nil {
+		return err
+	}
+	r.Base64EncodedPhoto = p
+	return nil
+}
+
+// Image decodes the photo, returning the image along with the name of its format, e.g.
+// "jpeg". ErrUnsupportedPhotoFormat is returned if the format of the image is not
+// recognised.
+func (p Photo) Image() (image.Image, string, error) {
+	data, err := base64.StdEncoding.DecodeString(p.Base64EncodedPhoto)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "unable to decode photo")
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err == image.ErrFormat {
+		return nil, "", ErrUnsupportedPhotoFormat
+	}
+	if err != nil {
+		return nil, "", errors.Wrap(err, "unable to decode photo")
+	}
+	return img, format, nil
+}
+
+// fit scales the image down, preserving its aspect ratio, so that neither side is longer
+// than max. Images that already fit are returned unchanged.
+func fit(img image.Image, max int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= max && h <= max {
+		return img
+	}
+
+	if w >= h {
+		h = h * max / w
+		w = max
+	} else {
+		w = w * max / h
+		h = max
+	}
+
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+	return resize(img, w, h)
+}
+
+// resize scales the image to the given size, averaging the source pixels that fall
+// within each destination pixel.
+func resize(src image.Image, w, h int) *image.RGBA {
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := b.Min.Y+y*sh/h, b.Min.Y+(y+1)*sh/h
+		if y1 == y0 {
+			y1++
+		}
+
+		for x := 0; x < w; x++ {
+			x0, x1 := b.Min.X+x*sw/w, b.Min.X+(x+1)*sw/w
+			if x1 == x0 {
+				x1++
+			}
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
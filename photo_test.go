@@ -0,0 +1,126 @@
+package starling
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func testImage(w, h int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	return img
+}
+
+var encodePhotoTC = []struct {
+	name string
+	w, h int
+	want image.Point
+}{
+	{name: "small image", w: 200, h: 100, want: image.Pt(200, 100)},
+	{name: "wide image", w: 2000, h: 1000, want: image.Pt(500, 250)},
+	{name: "tall image", w: 600, h: 1200, want: image.Pt(250, 500)},
+}
+
+func TestEncodePhoto(t *testing.T) {
+	for _, tc := range encodePhotoTC {
+		t.Run(tc.name, func(st *testing.T) {
+			enc, err := EncodePhoto(testImage(tc.w, tc.h))
+			checkNoError(st, err)
+
+			if len(enc) > MaxPhotoSize {
+				st.Error("should encode the photo within the maximum size", cross, len(enc))
+			}
+
+			img, format, err := Photo{Base64EncodedPhoto: enc}.Image()
+			checkNoError(st, err)
+
+			if format != "jpeg" {
+				st.Error("should encode the photo as a JPEG", cross, format)
+			}
+
+			if got := img.Bounds().Size(); got != tc.want {
+				st.Error("should scale the photo to fit the maximum dimension", cross, got)
+			}
+		})
+	}
+}
+
+func TestReadPhoto(t *testing.T) {
+	var buf bytes.Buffer
+	png.Encode(&buf, testImage(50, 40))
+
+	enc, err := ReadPhoto(&buf)
+	checkNoError(t, err)
+
+	img, _, err := Photo{Base64EncodedPhoto: enc}.Image()
+	checkNoError(t, err)
+
+	if got := img.Bounds().Size(); got != image.Pt(50, 40) {
+		t.Error("should preserve the size of a small photo", cross, got)
+	}
+}
+
+func TestReadPhotoUnsupported(t *testing.T) {
+	_, err := ReadPhoto(bytes.NewReader([]byte("BM this is not an image")))
+	if err != ErrUnsupportedPhotoFormat {
+		t.Error("should report the format as unsupported", cross, err)
+	}
+}
+
+func TestPhotoFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "photo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	name := filepath.Join(dir, "goal.png")
+	f, _ := os.Create(name)
+	png.Encode(f, testImage(800, 800))
+	f.Close()
+
+	var req SavingsGoalRequest
+	req.Base64EncodedPhoto, err = PhotoFile(name)
+	checkNoError(t, err)
+
+	img, _, err := Photo{Base64EncodedPhoto: req.Base64EncodedPhoto}.Image()
+	checkNoError(t, err)
+
+	if got := img.Bounds().Size(); got != image.Pt(500, 500) {
+		t.Error("should scale the photo read from the file", cross, got)
+	}
+
+	_, err = PhotoFile(filepath.Join(dir, "missing.png"))
+	checkHasError(t, err)
+}
+
+func TestSetPhoto(t *testing.T) {
+	var req SavingsGoalRequest
+	checkNoError(t, req.SetPhoto(testImage(10, 10)))
+
+	if req.Base64EncodedPhoto == "" {
+		t.Error("should set the photo on the request", cross)
+	}
+}
+
+func TestPhotoImageInvalid(t *testing.T) {
+	if _, _, err := (Photo{Base64EncodedPhoto: "not base 64!"}).Image(); err == nil {
+		t.Error("should return an error for invalid base 64", cross)
+	}
+
+	p := Photo{Base64EncodedPhoto: base64.StdEncoding.EncodeToString([]byte("not an image"))}
+	if _, _, err := p.Image(); err != ErrUnsupportedPhotoFormat {
+		t.Error("should report the format as unsupported", cross, err)
+	}
+}
@@ -0,0 +1,266 @@
+package starling
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Tenant describes how to authenticate requests made on behalf of a single customer.
+type Tenant struct {
+	// Transport wraps the shared pool transport with authentication for the customer, for
+	// example by returning an oauth2.Transport that uses base.
+	Transport func(base http.RoundTripper) http.RoundTripper
+
+	// Refresher, if set, refreshes the customer token when it is about to expire or has
+	// been rejected.
+	Refresher TokenRefresher
+}
+
+// PoolOptions is a set of options that can be specified when creating a ClientPool
+type PoolOptions struct {
+	BaseURL     *url.URL
+	CheckScopes bool
+
+	// Tenant is called to obtain the credentials for a customer the first time a client is
+	// requested for them.
+	Tenant func(ctx context.Context, customerUID string) (*Tenant, error)
+
+	// Transport is shared by every client in the pool. If nil, a transport tuned for many
+	// concurrent connections to the Starling API is used.
+	Transport http.RoundTripper
+
+	// RequestsPerSecond limits the rate of requests made by all clients in the pool, with
+	// bursts of up to Burst requests. The rate is not limited if it is zero.
+	RequestsPerSecond float64
+	Burst             int
+
+	// IdleTimeout is how long a client may go unused before it is evicted from the pool.
+	// Clients are not evicted if it is zero.
+	IdleTimeout time.Duration
+}
+
+// ClientPool creates and caches a Client for each customer of a multi-tenant application.
+// Clients share a transport and rate limit but authenticate with their own tokens. It is
+// safe for concurrent use.
+type ClientPool struct {
+	opts      PoolOptions
+	transport http.RoundTripper
+	now       func() time.Time
+
+	mu      sync.Mutex
+	clients map[string]*pooledClient
+}
+
+type pooledClient struct {
+	client   *Client
+	lastUsed time.Time
+}
+
+// NewClientPool returns a new ClientPool configured with opts.
+func NewClientPool(opts PoolOptions) *ClientPool {
+	if opts.BaseURL == nil {
+		opts.BaseURL, _ = url.Parse(defaultURL)
+	}
+
+	t := opts.Transport
+	if t == nil {
+		t = newTransport()
+	}
+
+	if opts.RequestsPerSecond > 0 {
+		t = &limitedTransport{base: t, limiter: newLimiter(opts.RequestsPerSecond, opts.Burst)}
+	}
+
+	return &ClientPool{
+		opts:      opts,
+		transport: t,
+		now:       time.Now,
+		clients:   map[string]*pooledClient{},
+	}
+}
+
+// Client returns the client for the customer, creating it if necessary. Idle clients are
+// evicted before the client is returned. An error is returned if the credentials for the
+// customer cannot be obtained.
+func (p *ClientPool) Client(ctx context.Context, customerUID string) (*Client, error) {
+	p.Evict()
+
+	p.mu.Lock()
+	if pc, ok := p.clients[customerUID]; ok {
+		pc.lastUsed = p.now()
+		p.mu.Unlock()
+		return pc.client, nil
+	}
+	p.mu.Unlock()
+
+	if p.opts.Tenant == nil {
+		return nil, errors.New("unable to create client: no tenant function configured")
+	}
+
+	tenant, err := p.opts.Tenant(ctx, customerUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to obtain credentials for customer "+customerUID)
+	}
+
+	t := p.transport
+	if tenant != nil && tenant.Transport != nil {
+		t = tenant.Transport(t)
+	}
+
+	opts := ClientOptions{BaseURL: p.opts.BaseURL, CheckScopes: p.opts.CheckScopes}
+	if tenant != nil {
+		opts.Refresher = tenant.Refresher
+	}
+	c := NewClientWithOptions(&http.Client{Transport: t}, opts)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Another caller may have created a client for the customer while the credentials were
+	// being obtained.
+	if pc, ok := p.clients[customerUID]; ok {
+		pc.lastUsed = p.now()
+		return pc.client, nil
+	}
+
+	p.clients[customerUID] = &pooledClient{client: c, lastUsed: p.now()}
+	return c, nil
+}
+
+// Remove removes the client for the customer from the pool, for example after the
+// customer revokes access.
+func (p *ClientPool) Remove(customerUID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, customerUID)
+}
+
+// Evict removes clients that have not been used for longer than the idle timeout and
+// returns the number of clients removed.
+func (p *ClientPool) Evict() int {
+	if p.opts.IdleTimeout == 0 {
+		return 0
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := 0
+	cutoff := p.now().Add(-p.opts.IdleTimeout)
+	for uid, pc := range p.clients {
+		if pc.lastUsed.Before(cutoff) {
+			delete(p.clients, uid)
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of clients in the pool.
+func (p *ClientPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.clients)
+}
+
+// Close removes every client from the pool and closes idle connections on the shared
+// transport.
+func (p *ClientPool) Close() {
+	p.mu.Lock()
+	p.clients = map[string]*pooledClient{}
+	p.mu.Unlock()
+
+	t := p.transport
+	if lt, ok := t.(*limitedTransport); ok {
+		t = lt.base
+	}
+	if ci, ok := t.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
+
+// newTransport returns a transport that keeps enough idle connections open to serve many
+// customers concurrently. All requests are made to a single host.
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// limitedTransport waits for the limiter before sending each request.
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *limiter
+}
+
+// RoundTrip sends the request once the rate limit allows.
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// limiter is a token bucket rate limiter.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newLimiter returns a limiter that allows rate events per second with bursts of up to
+// burst events. A burst of less than one is treated as one.
+func newLimiter(rate float64, burst int) *limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Wait blocks until an event is allowed or the context is done.
+func (l *limiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
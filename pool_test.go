@@ -0,0 +1,136 @@
+package starling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestClientPool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"customerUid": %q}`, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	baseURL, _ := url.Parse(server.URL + "/")
+
+	calls := map[string]int{}
+	pool := NewClientPool(PoolOptions{
+		BaseURL: baseURL,
+		Tenant: func(ctx context.Context, uid string) (*Tenant, error) {
+			calls[uid]++
+			if uid == "unknown" {
+				return nil, errors.New("no token for customer")
+			}
+
+			return &Tenant{Transport: func(base http.RoundTripper) http.RoundTripper {
+				return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+					r.Header.Set("Authorization", uid)
+					return base.RoundTrip(r)
+				})
+			}}, nil
+		},
+	})
+	defer pool.Close()
+
+	ctx := context.Background()
+	for _, uid := range []string{"alice", "bob", "alice"} {
+		c, err := pool.Client(ctx, uid)
+		checkNoError(t, err)
+
+		ident, _, err := c.CurrentUser(ctx)
+		checkNoError(t, err)
+
+		if ident.UID != uid {
+			t.Error("should authenticate requests with the customer credentials", cross, ident.UID)
+		}
+	}
+
+	if calls["alice"] != 1 || calls["bob"] != 1 {
+		t.Error("should only obtain credentials once per customer", cross, calls)
+	}
+
+	if pool.Len() != 2 {
+		t.Error("should hold one client per customer", cross, pool.Len())
+	}
+
+	a1, _ := pool.Client(ctx, "alice")
+	a2, _ := pool.Client(ctx, "alice")
+	if a1 != a2 {
+		t.Error("should reuse the client for a customer", cross)
+	}
+
+	if _, err := pool.Client(ctx, "unknown"); err == nil {
+		t.Error("should return an error if credentials cannot be obtained", cross)
+	}
+
+	if pool.Len() != 2 {
+		t.Error("should not add a client if credentials cannot be obtained", cross, pool.Len())
+	}
+
+	pool.Remove("bob")
+	if pool.Len() != 1 {
+		t.Error("should remove the client for a customer", cross, pool.Len())
+	}
+}
+
+func TestClientPoolEvict(t *testing.T) {
+	now := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	pool := NewClientPool(PoolOptions{
+		IdleTimeout: 10 * time.Minute,
+		Tenant: func(ctx context.Context, uid string) (*Tenant, error) {
+			return &Tenant{}, nil
+		},
+	})
+	pool.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	pool.Client(ctx, "alice")
+	pool.Client(ctx, "bob")
+
+	now = now.Add(6 * time.Minute)
+	pool.Client(ctx, "alice")
+
+	now = now.Add(6 * time.Minute)
+	if n := pool.Evict(); n != 1 {
+		t.Error("should evict clients that have been idle for longer than the timeout", cross, n)
+	}
+
+	if pool.Len() != 1 {
+		t.Error("should keep clients that have been used recently", cross, pool.Len())
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter(50, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		checkNoError(t, l.Wait(ctx))
+	}
+
+	// Two requests are allowed immediately and three more at 20ms intervals.
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Error("should limit the rate of requests", cross, d)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	l = newLimiter(0.001, 1)
+	l.Wait(ctx)
+	if err := l.Wait(ctx); err == nil {
+		t.Error("should stop waiting when the context is cancelled", cross)
+	}
+}
@@ -0,0 +1,268 @@
+package starling
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Frequencies supported by a RecurrenceRule
+const (
+	FrequencyDaily   = "DAILY"
+	FrequencyWeekly  = "WEEKLY"
+	FrequencyMonthly = "MONTHLY"
+	FrequencyYearly  = "YEARLY"
+)
+
+// dateLayout is the layout of the dates used in a RecurrenceRule
+const dateLayout = "2006-01-02"
+
+// weekdays maps the WeekStart values used by Starling to RFC 5545 weekday codes
+var weekdays = map[string]string{
+	"MONDAY":    "MO",
+	"TUESDAY":   "TU",
+	"WEDNESDAY": "WE",
+	"THURSDAY":  "TH",
+	"FRIDAY":    "FR",
+	"SATURDAY":  "SA",
+	"SUNDAY":    "SU",
+}
+
+// Validate checks that the combination of frequency, interval, count and until date in a
+// RecurrenceRule is one that can be expanded. A rule must have a start date and may be
+// limited by a count or an until date, but not both.
+func (r RecurrenceRule) Validate() error {
+	start, err := time.Parse(dateLayout, r.StartDate)
+	if err != nil {
+		return errors.Wrap(err, "invalid recurrence rule: unable to parse start date")
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	default:
+		return fmt.Errorf("invalid recurrence rule: unsupported frequency %q", r.Frequency)
+	}
+
+	if r.Interval < 0 {
+		return fmt.Errorf("invalid recurrence rule: interval must not be negative: %d", r.Interval)
+	}
+
+	if r.Count < 0 {
+		return fmt.Errorf("invalid recurrence rule: count must not be negative: %d", r.Count)
+	}
+
+	if r.UntilDate != "" {
+		if r.Count != 0 {
+			return errors.New("invalid recurrence rule: count and until date must not both be set")
+		}
+
+		until, err := time.Parse(dateLayout, r.UntilDate)
+		if err != nil {
+			return errors.Wrap(err, "invalid recurrence rule: unable to parse until date")
+		}
+
+		if until.Before(start) {
+			return errors.New("invalid recurrence rule: until date is before start date")
+		}
+	}
+
+	if _, ok := weekdays[r.WeekStart]; r.WeekStart != "" && !ok {
+		return fmt.Errorf("invalid recurrence rule: unsupported week start %q", r.WeekStart)
+	}
+
+	return nil
+}
+
+// Occurrences returns up to n dates on which the rule occurs, starting on or after from.
+// Occurrences before from still count towards the rule count. Where the day of the start
+// date does not exist in a month, such as the 31st, the last day of the month is used.
+// Dates are returned as midnight UTC. An error is returned if the rule is invalid or n is
+// not positive.
+func (r RecurrenceRule) Occurrences(from time.Time, n int) ([]time.Time, error) {
+	if n <= 0 {
+		return nil, errors.New("unable to expand recurrence rule without a positive number of occurrences")
+	}
+	return r.expand(from, time.Time{}, n)
+}
+
+// Between returns the dates on which the rule occurs between from and to inclusive. An
+// error is returned if the rule is invalid.
+func (r RecurrenceRule) Between(from, to time.Time) ([]time.Time, error) {
+	return r.expand(from, to, 0)
+}
+
+// PaymentDates returns up to n dates on which payments made according to the rule will
+// be taken. Occurrences that fall on a weekend are moved to the following Monday.
+func (r RecurrenceRule) PaymentDates(from time.Time, n int) ([]time.Time, error) {
+	dates, err := r.Occurrences(from, n)
+	for i, d := range dates {
+		dates[i] = NextWorkingDay(d)
+	}
+	return dates, err
+}
+
+// expand returns the occurrences of the rule on or after from. Expansion stops after to if
+// it is non-zero and after limit occurrences if limit is non-zero.
+func (r RecurrenceRule) expand(from, to time.Time, limit int) ([]time.Time, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	if to.IsZero() && limit == 0 {
+		return nil, errors.New("unable to expand recurrence rule without a limit")
+	}
+
+	start, _ := time.Parse(dateLayout, r.StartDate)
+	until, _ := time.Parse(dateLayout, r.UntilDate)
+	from = midnight(from)
+	if !to.IsZero() {
+		to = midnight(to)
+	}
+
+	interval := int(r.Interval)
+	if interval == 0 {
+		interval = 1
+	}
+
+	dates := []time.Time{}
+	for i := 0; r.Count == 0 || i < int(r.Count); i++ {
+		var d time.Time
+		switch r.Frequency {
+		case FrequencyDaily:
+			d = start.AddDate(0, 0, i*interval)
+		case FrequencyWeekly:
+			d = start.AddDate(0, 0, 7*i*interval)
+		case FrequencyMonthly:
+			d = addMonths(start, i*interval)
+		case FrequencyYearly:
+			d = addMonths(start, 12*i*interval)
+		}
+
+		if (!until.IsZero() && d.After(until)) || (!to.IsZero() && d.After(to)) {
+			break
+		}
+
+		if !d.Before(from) {
+			dates = append(dates, d)
+		}
+
+		if limit != 0 && len(dates) == limit {
+			break
+		}
+	}
+	return dates, nil
+}
+
+// RRULE returns the rule as an RFC 5545 RRULE value, e.g. FREQ=MONTHLY;INTERVAL=2. The
+// start date is not part of the RRULE and should be provided as the DTSTART of the event.
+// An error is returned if the rule is invalid.
+func (r RecurrenceRule) RRULE() (string, error) {
+	if err := r.Validate(); err != nil {
+		return "", err
+	}
+
+	parts := []string{"FREQ=" + r.Frequency}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(int(r.Interval)))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(int(r.Count)))
+	}
+	if r.UntilDate != "" {
+		parts = append(parts, "UNTIL="+strings.Replace(r.UntilDate, "-", "", -1))
+	}
+	if r.WeekStart != "" {
+		parts = append(parts, "WKST="+weekdays[r.WeekStart])
+	}
+	return strings.Join(parts, ";"), nil
+}
+
+// ParseRRULE parses an RFC 5545 RRULE value, with or without the RRULE: prefix, into a
+// RecurrenceRule that starts on the given date. Only the FREQ, INTERVAL, COUNT, UNTIL and
+// WKST parts are supported. An error is returned if the value cannot be parsed or the
+// resulting rule is invalid.
+func ParseRRULE(s string, start time.Time) (RecurrenceRule, error) {
+	r := RecurrenceRule{StartDate: start.Format(dateLayout)}
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return RecurrenceRule{}, fmt.Errorf("unable to parse RRULE part %q", part)
+		}
+
+		k, v := strings.ToUpper(kv[0]), kv[1]
+		switch k {
+		case "FREQ":
+			r.Frequency = strings.ToUpper(v)
+		case "INTERVAL", "COUNT":
+			n, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				return RecurrenceRule{}, errors.Wrap(err, "unable to parse RRULE "+k)
+			}
+			if k == "INTERVAL" {
+				r.Interval = int32(n)
+			} else {
+				r.Count = int32(n)
+			}
+		case "UNTIL":
+			if len(v) < 8 {
+				return RecurrenceRule{}, fmt.Errorf("unable to parse RRULE UNTIL %q", v)
+			}
+			until, err := time.Parse("20060102", v[:8])
+			if err != nil {
+				return RecurrenceRule{}, errors.Wrap(err, "unable to parse RRULE UNTIL")
+			}
+			r.UntilDate = until.Format(dateLayout)
+		case "WKST":
+			for day, code := range weekdays {
+				if code == strings.ToUpper(v) {
+					r.WeekStart = day
+				}
+			}
+			if r.WeekStart == "" {
+				return RecurrenceRule{}, fmt.Errorf("unable to parse RRULE WKST %q", v)
+			}
+		default:
+			return RecurrenceRule{}, fmt.Errorf("unsupported RRULE part %q", k)
+		}
+	}
+
+	if err := r.Validate(); err != nil {
+		return RecurrenceRule{}, err
+	}
+	return r, nil
+}
+
+// NextWorkingDay returns t if it falls on a weekday, otherwise the following Monday.
+// Bank holidays are not taken into account.
+func NextWorkingDay(t time.Time) time.Time {
+	switch t.Weekday() {
+	case time.Saturday:
+		return t.AddDate(0, 0, 2)
+	case time.Sunday:
+		return t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+// addMonths adds n months to t, keeping the time of day. If the day of the month does not
+// exist in the resulting month the last day of that month is used, so 31 January plus one
+// month is 28 or 29 February rather than early March.
+func addMonths(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	if last := first.AddDate(0, 1, -1).Day(); d > last {
+		d = last
+	}
+	return first.AddDate(0, 0, d-1)
+}
+
+// midnight returns midnight UTC on the calendar day of t.
+func midnight(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
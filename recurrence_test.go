@@ -0,0 +1,267 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+var recurrenceValidateTC = []struct {
+	name  string
+	rule  RecurrenceRule
+	valid bool
+}{
+	{name: "monthly", rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "MONTHLY"}, valid: true},
+	{name: "with count", rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "WEEKLY", Interval: 2, Count: 4, WeekStart: "MONDAY"}, valid: true},
+	{name: "with until date", rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "DAILY", UntilDate: "2019-02-28"}, valid: true},
+	{name: "missing start date", rule: RecurrenceRule{Frequency: "MONTHLY"}},
+	{name: "unsupported frequency", rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "HOURLY"}},
+	{name: "negative interval", rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "MONTHLY", Interval: -1}},
+	{name: "negative count", rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "MONTHLY", Count: -1}},
+	{name: "count and until date", rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "MONTHLY", Count: 2, UntilDate: "2019-06-01"}},
+	{name: "until before start", rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "MONTHLY", UntilDate: "2018-06-01"}},
+	{name: "invalid week start", rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "MONTHLY", WeekStart: "MO"}},
+}
+
+func TestRecurrenceRuleValidate(t *testing.T) {
+	for _, tc := range recurrenceValidateTC {
+		t.Run(tc.name, func(st *testing.T) {
+			err := tc.rule.Validate()
+			if tc.valid {
+				checkNoError(st, err)
+			} else {
+				checkHasError(st, err)
+			}
+		})
+	}
+}
+
+var recurrenceBetweenTC = []struct {
+	name string
+	rule RecurrenceRule
+	from time.Time
+	to   time.Time
+	want []time.Time
+}{
+	{
+		name: "weekly",
+		rule: RecurrenceRule{StartDate: "2019-01-01", Frequency: "WEEKLY"},
+		from: date(2019, 1, 10),
+		to:   date(2019, 1, 31),
+		want: []time.Time{date(2019, 1, 15), date(2019, 1, 22), date(2019, 1, 29)},
+	},
+	{
+		name: "monthly from the end of the month",
+		rule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "MONTHLY"},
+		from: date(2019, 1, 1),
+		to:   date(2019, 4, 30),
+		want: []time.Time{date(2019, 1, 31), date(2019, 2, 28), date(2019, 3, 31), date(2019, 4, 30)},
+	},
+	{
+		name: "fortnightly with a count",
+		rule: RecurrenceRule{StartDate: "2019-01-01", Frequency: "WEEKLY", Interval: 2, Count: 3},
+		from: date(2019, 1, 10),
+		to:   date(2019, 12, 31),
+		want: []time.Time{date(2019, 1, 15), date(2019, 1, 29)},
+	},
+	{
+		name: "yearly until a date",
+		rule: RecurrenceRule{StartDate: "2020-02-29", Frequency: "YEARLY", UntilDate: "2024-03-01"},
+		from: date(2019, 1, 1),
+		to:   date(2030, 1, 1),
+		want: []time.Time{date(2020, 2, 29), date(2021, 2, 28), date(2022, 2, 28), date(2023, 2, 28), date(2024, 2, 29)},
+	},
+}
+
+func TestRecurrenceRuleBetween(t *testing.T) {
+	for _, tc := range recurrenceBetweenTC {
+		t.Run(tc.name, func(st *testing.T) {
+			got, err := tc.rule.Between(tc.from, tc.to)
+			checkNoError(st, err)
+
+			if len(got) != len(tc.want) {
+				st.Fatal("should return the expected number of occurrences", cross, got)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tc.want[i]) {
+					st.Error("should return the expected occurrence", cross, got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	tcs := []struct {
+		in   time.Time
+		n    int
+		want time.Time
+	}{
+		{in: time.Date(2019, 1, 31, 0, 0, 0, 0, time.UTC), n: 1, want: time.Date(2019, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{in: time.Date(2020, 1, 31, 0, 0, 0, 0, time.UTC), n: 1, want: time.Date(2020, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{in: time.Date(2019, 12, 15, 0, 0, 0, 0, time.UTC), n: 1, want: time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{in: time.Date(2019, 8, 31, 0, 0, 0, 0, time.UTC), n: 3, want: time.Date(2019, 11, 30, 0, 0, 0, 0, time.UTC)},
+		{in: time.Date(2019, 3, 31, 23, 30, 0, 0, time.UTC), n: -1, want: time.Date(2019, 2, 28, 23, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tcs {
+		if got := addMonths(tc.in, tc.n); !got.Equal(tc.want) {
+			t.Error("should clamp to the end of the month", cross, tc.in, got)
+		}
+	}
+}
+
+func TestRecurrenceRuleOccurrences(t *testing.T) {
+	r := RecurrenceRule{StartDate: "2019-08-31", Frequency: "MONTHLY"}
+
+	got, err := r.Occurrences(time.Date(2019, 9, 15, 13, 0, 0, 0, time.UTC), 3)
+	checkNoError(t, err)
+
+	want := []time.Time{date(2019, 9, 30), date(2019, 10, 31), date(2019, 11, 30)}
+	if len(got) != len(want) {
+		t.Fatal("should return the requested number of occurrences", cross, got)
+	}
+
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Error("should return the expected occurrence", cross, got[i])
+		}
+	}
+
+	if _, err := r.Occurrences(time.Now(), 0); err == nil {
+		t.Error("should not expand an unbounded rule without a limit", cross)
+	}
+
+	if _, err := r.Occurrences(time.Now(), -1); err == nil {
+		t.Error("should not expand a rule with a negative limit", cross)
+	}
+
+	bounded := RecurrenceRule{StartDate: "2019-08-31", Frequency: "MONTHLY", Count: 3}
+	if _, err := bounded.Occurrences(time.Now(), -1); err == nil {
+		t.Error("should not expand a bounded rule with a negative limit", cross)
+	}
+
+	if _, err := (RecurrenceRule{Frequency: "MONTHLY"}).Occurrences(time.Now(), 3); err == nil {
+		t.Error("should not expand an invalid rule", cross)
+	}
+}
+
+func TestRecurrenceRulePaymentDates(t *testing.T) {
+	r := RecurrenceRule{StartDate: "2019-08-31", Frequency: "MONTHLY"}
+
+	got, err := r.PaymentDates(date(2019, 8, 1), 2)
+	checkNoError(t, err)
+
+	if !got[0].Equal(date(2019, 9, 2)) {
+		t.Error("should move a Saturday payment to the following Monday", cross, got[0])
+	}
+
+	if !got[1].Equal(date(2019, 9, 30)) {
+		t.Error("should not move a weekday payment", cross, got[1])
+	}
+}
+
+var rruleTC = []struct {
+	name  string
+	rule  RecurrenceRule
+	rrule string
+}{
+	{
+		name:  "monthly",
+		rule:  RecurrenceRule{StartDate: "2019-01-31", Frequency: "MONTHLY"},
+		rrule: "FREQ=MONTHLY",
+	},
+	{
+		name:  "fortnightly with a count",
+		rule:  RecurrenceRule{StartDate: "2019-01-31", Frequency: "WEEKLY", Interval: 2, Count: 6, WeekStart: "MONDAY"},
+		rrule: "FREQ=WEEKLY;INTERVAL=2;COUNT=6;WKST=MO",
+	},
+	{
+		name:  "daily until a date",
+		rule:  RecurrenceRule{StartDate: "2019-01-31", Frequency: "DAILY", UntilDate: "2019-03-01"},
+		rrule: "FREQ=DAILY;UNTIL=20190301",
+	},
+}
+
+func TestRecurrenceRuleRRULE(t *testing.T) {
+	for _, tc := range rruleTC {
+		t.Run(tc.name, func(st *testing.T) {
+			got, err := tc.rule.RRULE()
+			checkNoError(st, err)
+
+			if got != tc.rrule {
+				st.Error("should format the rule as an RRULE", cross, got)
+			}
+
+			parsed, err := ParseRRULE("RRULE:"+got, date(2019, 1, 31))
+			checkNoError(st, err)
+
+			if parsed != tc.rule {
+				st.Error("should parse the RRULE into the original rule", cross, parsed)
+			}
+		})
+	}
+}
+
+func TestParseRRULE(t *testing.T) {
+	got, err := ParseRRULE("FREQ=YEARLY;UNTIL=20250101T000000Z", date(2019, 1, 31))
+	checkNoError(t, err)
+
+	if got.UntilDate != "2025-01-01" {
+		t.Error("should parse an UNTIL date-time", cross, got.UntilDate)
+	}
+
+	invalid := []string{
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=MONTHLY;COUNT=many",
+		"FREQ=MONTHLY;WKST=XX",
+		"FREQ=SECONDLY",
+		"MONTHLY",
+	}
+
+	for _, s := range invalid {
+		if _, err := ParseRRULE(s, date(2019, 1, 31)); err == nil {
+			t.Error("should return an error for an unsupported RRULE", cross, s)
+		}
+	}
+}
+
+func TestCreateScheduledPaymentInvalidRule(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/payments/scheduled", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not call the API with an invalid recurrence rule", cross)
+	})
+
+	p := ScheduledPayment{Schedule: RecurrenceRule{StartDate: "2019-01-31", Frequency: "FORTNIGHTLY"}}
+	_, resp, err := client.CreateScheduledPayment(context.Background(), p)
+	checkHasError(t, err)
+
+	if resp != nil {
+		t.Error("should not return a response", cross)
+	}
+}
+
+func TestCreateRecurringTransferInvalidRule(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/savings-goals/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not call the API with an invalid recurrence rule", cross)
+	})
+
+	rtr := RecurringTransferRequest{RecurrenceRule: RecurrenceRule{Frequency: "WEEKLY"}}
+	_, resp, err := client.CreateRecurringTransfer(context.Background(), "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", rtr)
+	checkHasError(t, err)
+
+	if resp != nil {
+		t.Error("should not return a response", cross)
+	}
+}
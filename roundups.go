@@ -0,0 +1,82 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Limits on the multiplier applied to round-ups
+const (
+	MinRoundUpMultiplier = 1
+	MaxRoundUpMultiplier = 10
+)
+
+// RoundUpGoal is the savings goal into which round-ups on an account are saved
+type RoundUpGoal struct {
+	GoalUID    string `json:"roundUpGoalUid"`
+	Multiplier int32  `json:"roundUpMultiplier"`
+}
+
+// ItemRoundUp is the round-up saved from a feed item
+type ItemRoundUp struct {
+	GoalCategoryUID string `json:"goalCategoryUid"`
+	Amount          Amount `json:"amount"`
+}
+
+// RoundUp returns the savings goal into which round-ups on an account are saved. Nil is
+// returned if round-ups are not active on the account.
+//
+// Note: RoundUp uses the v2 API which is still under active development.
+func (c *Client) RoundUp(ctx context.Context, act string) (*RoundUpGoal, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/feed/account/"+act+"/round-up", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rug *RoundUpGoal
+	resp, err := c.Do(ctx, req, &rug)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, resp, nil
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if rug != nil && rug.GoalUID == "" {
+		rug = nil
+	}
+	return rug, resp, nil
+}
+
+// ActivateRoundUp starts saving round-ups on outgoing card payments from an account into
+// a savings goal. Each round-up is multiplied by the multiplier. The multiplier must be
+// between MinRoundUpMultiplier and MaxRoundUpMultiplier; otherwise an error is returned
+// without calling the API.
+//
+// Note: ActivateRoundUp uses the v2 API which is still under active development.
+func (c *Client) ActivateRoundUp(ctx context.Context, act, goalUID string, multiplier int32) (*http.Response, error) {
+	if multiplier < MinRoundUpMultiplier || multiplier > MaxRoundUpMultiplier {
+		return nil, errors.Errorf("round-up multiplier must be between %d and %d", MinRoundUpMultiplier, MaxRoundUpMultiplier)
+	}
+
+	req, err := c.NewRequest("PUT", "/api/v2/feed/account/"+act+"/round-up", RoundUpGoal{GoalUID: goalUID, Multiplier: multiplier})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, nil)
+}
+
+// DeactivateRoundUp stops saving round-ups on an account.
+//
+// Note: DeactivateRoundUp uses the v2 API which is still under active development.
+func (c *Client) DeactivateRoundUp(ctx context.Context, act string) (*http.Response, error) {
+	req, err := c.NewRequest("DELETE", "/api/v2/feed/account/"+act+"/round-up", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, nil)
+}
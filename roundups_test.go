@@ -0,0 +1,150 @@
+package starling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+var roundUpTC = []struct {
+	name   string
+	status int
+	mock   string
+	want   *RoundUpGoal
+}{
+	{
+		name:   "active round-up",
+		status: http.StatusOK,
+		mock:   `{"roundUpGoalUid": "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", "roundUpMultiplier": 2}`,
+		want:   &RoundUpGoal{GoalUID: "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", Multiplier: 2},
+	},
+	{
+		name:   "inactive round-up",
+		status: http.StatusOK,
+		mock:   `{}`,
+	},
+	{
+		name:   "round-up not found",
+		status: http.StatusNotFound,
+		mock:   `{}`,
+	},
+}
+
+// TestRoundUp confirms that the client is able to query the round-up goal for an account.
+func TestRoundUp(t *testing.T) {
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+
+	for _, tc := range roundUpTC {
+		t.Run(tc.name, func(st *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/api/v2/feed/account/"+act+"/round-up", func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(st, r, "GET")
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.mock)
+			})
+
+			got, _, err := client.RoundUp(context.Background(), act)
+			checkNoError(st, err)
+
+			if tc.want == nil {
+				if got != nil {
+					st.Error("should return 'nil' when round-ups are not active", cross, got)
+				}
+				return
+			}
+
+			if got == nil || *got != *tc.want {
+				st.Error("should return the round-up goal matching the mock response", cross, got)
+			}
+		})
+	}
+}
+
+// TestActivateRoundUp confirms that the client is able to activate round-ups on an account.
+func TestActivateRoundUp(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	want := RoundUpGoal{GoalUID: "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", Multiplier: 3}
+
+	mux.HandleFunc("/api/v2/feed/account/"+act+"/round-up", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+
+		var got RoundUpGoal
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal("should send a request that the API can parse", cross, err)
+		}
+
+		if got != want {
+			t.Error("should send the goal and multiplier", cross, got)
+		}
+	})
+
+	resp, err := client.ActivateRoundUp(context.Background(), act, want.GoalUID, want.Multiplier)
+	checkNoError(t, err)
+	checkStatus(t, resp, http.StatusOK)
+}
+
+// TestActivateRoundUp_InvalidMultiplier confirms that the client rejects a multiplier the
+// API does not support without calling the API.
+func TestActivateRoundUp_InvalidMultiplier(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/feed/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not call the API", cross)
+	})
+
+	for _, m := range []int32{0, 11} {
+		_, err := client.ActivateRoundUp(context.Background(), "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e", "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", m)
+		checkHasError(t, err)
+	}
+}
+
+// TestDeactivateRoundUp confirms that the client is able to deactivate round-ups on an
+// account.
+func TestDeactivateRoundUp(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+
+	mux.HandleFunc("/api/v2/feed/account/"+act+"/round-up", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.DeactivateRoundUp(context.Background(), act)
+	checkNoError(t, err)
+	checkStatus(t, resp, http.StatusNoContent)
+}
+
+// TestItemRoundUp confirms that the round-up saved from a card payment is decoded from a
+// feed item.
+func TestItemRoundUp(t *testing.T) {
+	mock := `{
+		"feedItemUid": "dbb59f1c-39e6-4558-87ba-11c142965393",
+		"amount": {"currency": "GBP", "minorUnits": 235},
+		"direction": "OUT",
+		"source": "MASTER_CARD",
+		"roundUp": {
+			"goalCategoryUid": "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b",
+			"amount": {"currency": "GBP", "minorUnits": 65}
+		}
+	}`
+
+	var itm Item
+	if err := json.Unmarshal([]byte(mock), &itm); err != nil {
+		t.Fatal("should decode the feed item", cross, err)
+	}
+
+	want := ItemRoundUp{GoalCategoryUID: "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", Amount: Amount{Currency: "GBP", MinorUnits: 65}}
+	if itm.RoundUp == nil || *itm.RoundUp != want {
+		t.Error("should include the round-up saved from the payment", cross, itm.RoundUp)
+	}
+}
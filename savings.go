@@ -2,11 +2,9 @@ package starling
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"strings"
 
-	"github.com/google/uuid"
+	"github.com/pkg/errors"
 )
 
 // SavingsGoal is a goal defined by a customer to hold savings
@@ -38,14 +36,6 @@ type savingsGoalResponse struct {
 	Errors  []ErrorDetail `json:"errors"`
 }
 
-// SavingsGoalTransferResponse represents the response received after attempting to make an immediate or recurring transfer
-// into/out of a savings goal.
-type savingsGoalTransferResponse struct {
-	UID     string        `json:"transferUid"` // Unique identifier for the transfer
-	Success bool          `json:"success"`     // True if the method completed successfully
-	Errors  []ErrorDetail `json:"errors"`      // List of errors if the method request failed
-}
-
 // WithdrawalRequest is a request to withdraw money from a savings goal
 type withdrawalRequest struct {
 	Amount `json:"amount"`
@@ -109,64 +99,53 @@ func (c *Client) CreateSavingsGoal(ctx context.Context, uid string, sgReq Saving
 		return nil, err
 	}
 
-	var sgResp *savingsGoalResponse
-	resp, err := c.Do(ctx, req, &sgResp)
-	if err != nil {
-		return resp, err
-	}
-
-	ers := make([]string, len(sgResp.Errors))
-	for i, v := range sgResp.Errors {
-		ers[i] = v.Message
-	}
-
-	if sgResp.Success != true {
-		return resp, fmt.Errorf(strings.Join(ers, ", "))
-	}
-
-	return resp, nil
+	_, resp, err := c.doSavingsGoal(ctx, req)
+	return resp, err
 }
 
 // TransferToSavingsGoal transfers money into a savings goal. It returns the http response in case this is required for further
-// processing. An error will be returned if the API is unable to transfer the amount into the savings goal.
+// processing. An error will be returned if the API is unable to transfer the amount into the savings goal. Each call is made with
+// a new transfer UID; use TransferToSavingsGoalWithUID if the transfer may need to be retried.
 func (c *Client) TransferToSavingsGoal(ctx context.Context, goalUID string, a Amount) (string, *http.Response, error) {
-	txnUID, err := uuid.NewRandom()
-	if err != nil {
-		return "", nil, err
-	}
-
-	req, err := c.NewRequest("PUT", "/api/v1/savings-goals/"+goalUID+"/add-money/"+txnUID.String(), topUpRequest{Amount: a})
-	if err != nil {
-		return "", nil, err
-	}
-
-	tuResp := new(savingsGoalTransferResponse)
-	resp, err := c.Do(ctx, req, &tuResp)
-	if err != nil {
+	tr, resp, err := c.transfer(ctx, "/api/v1/savings-goals/"+goalUID+"/add-money", "", a)
+	if tr == nil {
 		return "", resp, err
 	}
-	return tuResp.UID, resp, nil
+	return tr.UID, resp, err
+}
+
+// TransferToSavingsGoalWithUID transfers money into a savings goal using the transfer UID provided by the caller, see
+// TransferResult. An error will be returned along with the result if the API reports that the transfer failed.
+func (c *Client) TransferToSavingsGoalWithUID(ctx context.Context, goalUID, txnUID string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.transferWithBalance(ctx, "/api/v1/savings-goals/"+goalUID+"/add-money", txnUID, a, c.savingsGoalTotal(goalUID))
 }
 
 // TransferFromSavingsGoal transfers money out of a savings goal. It returns the http response in case this is required for further
-// processing. An error will be returned if the API is unable to transfer the amount out of the savings goal.
+// processing. An error will be returned if the API is unable to transfer the amount out of the savings goal. Each call is made with
+// a new transfer UID; use TransferFromSavingsGoalWithUID if the transfer may need to be retried.
 func (c *Client) TransferFromSavingsGoal(ctx context.Context, goalUID string, a Amount) (string, *http.Response, error) {
-	txnUID, err := uuid.NewRandom()
-	if err != nil {
-		return "", nil, err
+	tr, resp, err := c.transfer(ctx, "/api/v1/savings-goals/"+goalUID+"/withdraw-money", "", a)
+	if tr == nil {
+		return "", resp, err
 	}
+	return tr.UID, resp, err
+}
 
-	req, err := c.NewRequest("PUT", "/api/v1/savings-goals/"+goalUID+"/withdraw-money/"+txnUID.String(), topUpRequest{Amount: a})
-	if err != nil {
-		return "", nil, err
-	}
+// TransferFromSavingsGoalWithUID transfers money out of a savings goal using the transfer UID provided by the caller, see
+// TransferResult. An error will be returned along with the result if the API reports that the transfer failed.
+func (c *Client) TransferFromSavingsGoalWithUID(ctx context.Context, goalUID, txnUID string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.transferWithBalance(ctx, "/api/v1/savings-goals/"+goalUID+"/withdraw-money", txnUID, a, c.savingsGoalTotal(goalUID))
+}
 
-	var tuResp *savingsGoalTransferResponse
-	resp, err := c.Do(ctx, req, &tuResp)
-	if err != nil {
-		return "", resp, err
+// savingsGoalTotal returns a function that retrieves the total saved in a savings goal.
+func (c *Client) savingsGoalTotal(goalUID string) func(context.Context) (*Amount, error) {
+	return func(ctx context.Context) (*Amount, error) {
+		goal, _, err := c.SavingsGoal(ctx, goalUID)
+		if err != nil || goal == nil {
+			return nil, err
+		}
+		return &goal.TotalSaved, nil
 	}
-	return tuResp.UID, resp, nil
 }
 
 // DeleteSavingsGoal deletes a savings goal for the current customer. It returns http.StatusNoContent
@@ -219,19 +198,32 @@ func (c *Client) RecurringTransfer(ctx context.Context, uid string) (*RecurringT
 
 // CreateRecurringTransfer sets up the recurring transfer for a savings goal. It takes the UID of the savings goal, along with a RecurringTransferRequest
 // and returns the UID of the recurring transfer. It also returns the http response in case this is required for further processing. An error is returned
-// on failure.
+// on failure, including when the API reports that the transfer could not be set up or when the RecurrenceRule is invalid, in which case the API is not
+// called.
 func (c *Client) CreateRecurringTransfer(ctx context.Context, uid string, rtr RecurringTransferRequest) (string, *http.Response, error) {
+	if err := rtr.RecurrenceRule.Validate(); err != nil {
+		return "", nil, err
+	}
+
 	req, err := c.NewRequest("PUT", "/api/v1/savings-goals/"+uid+"/recurring-transfer", rtr)
 	if err != nil {
 		return "", nil, err
 	}
 
-	var tuResp *savingsGoalTransferResponse
-	resp, err := c.Do(ctx, req, &tuResp)
+	var tr *TransferResult
+	resp, err := c.Do(ctx, req, &tr)
 	if err != nil {
 		return "", resp, err
 	}
-	return "", resp, nil
+
+	if tr == nil {
+		return "", resp, errors.New("no transfer result returned")
+	}
+
+	if !tr.Success {
+		return tr.UID, resp, detailErrors(tr.Errors)
+	}
+	return tr.UID, resp, nil
 }
 
 // DeleteRecurringTransfer deletes the recurring transfer for a savings goal. It takes the UID of the savings goal and returns no content. It returns the
@@ -309,7 +309,7 @@ func TestTransferToSavingsGoal(t *testing.T) {
 		t.Fatal("should be able to make the request", cross, err)
 	}
 
-	want := &savingsGoalTransferResponse{}
+	want := &TransferResult{}
 	json.Unmarshal([]byte(mockResp), want)
 
 	if got, want := resp.StatusCode, http.StatusOK; got != want {
@@ -578,7 +578,7 @@ func TestTransferFromSavingsGoal(t *testing.T) {
 		t.Fatal("should be able to make the request", cross, err)
 	}
 
-	want := &savingsGoalTransferResponse{}
+	want := &TransferResult{}
 	json.Unmarshal([]byte(mockResp), want)
 
 	if got, want := resp.StatusCode, http.StatusOK; got != want {
@@ -814,6 +814,7 @@ func testCreateRecurringTransfer(t *testing.T, name, uid string, mock string) {
 		UID:    "123",
 		Amount: Amount{Currency: "GBP", MinorUnits: 1234},
 		RecurrenceRule: RecurrenceRule{
+			StartDate: "2017-09-23",
 			Frequency: "DAILY",
 			Interval:  2,
 			Count:     4,
@@ -827,8 +828,8 @@ func testCreateRecurringTransfer(t *testing.T, name, uid string, mock string) {
 		t.Error("should return an HTTP 200 status", cross, resp.Status)
 	}
 
-	if id == "28dff346-dd48-426f-96df-d7f33d29c379" {
-		t.Error("should return a UID", cross)
+	if id != "28dff346-dd48-426f-96df-d7f33d29c379" {
+		t.Error("should return the UID of the recurring transfer", cross, id)
 	}
 }
 
@@ -844,7 +845,7 @@ func TestCreateRecurringTransferForbidden(t *testing.T) {
 	rtrReq := RecurringTransferRequest{
 		UID:            "123",
 		Amount:         Amount{Currency: "GBP", MinorUnits: 1234},
-		RecurrenceRule: RecurrenceRule{Frequency: "DAILY", Interval: 2, Count: 4},
+		RecurrenceRule: RecurrenceRule{StartDate: "2017-09-23", Frequency: "DAILY", Interval: 2, Count: 4},
 	}
 
 	got, resp, err := client.CreateRecurringTransfer(context.Background(), "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", rtrReq)
@@ -919,3 +920,105 @@ func TestDeleteRecurringTransferForbidden(t *testing.T) {
 		t.Error("should return HTTP 403 status")
 	}
 }
+
+// TestTransferToSavingsGoalWithUID confirms that the client sends the transfer UID supplied
+// by the caller and returns the resulting balance of the savings goal.
+func TestTransferToSavingsGoalWithUID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	goalUID := "d8770f9d-4ee9-4cc1-86e1-83c26bcfcc4f"
+	txnUID := "28dff346-dd48-426f-96df-d7f33d29c379"
+	mockAmount := Amount{Currency: "GBP", MinorUnits: 1050}
+
+	mux.HandleFunc("/api/v1/savings-goals/"+goalUID+"/add-money/", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+
+		if reqUID := path.Base(r.URL.Path); reqUID != txnUID {
+			t.Error("should send the transfer UID supplied by the caller", cross, reqUID)
+		}
+
+		fmt.Fprintf(w, `{"transferUid": "%s", "success": true, "errors": []}`, txnUID)
+	})
+
+	mux.HandleFunc("/api/v1/savings-goals/"+goalUID, func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"uid": "%s", "totalSaved": {"currency": "GBP", "minorUnits": 6050}}`, goalUID)
+	})
+
+	for i := 0; i < 2; i++ {
+		got, _, err := client.TransferToSavingsGoalWithUID(context.Background(), goalUID, txnUID, mockAmount)
+		checkNoError(t, err)
+
+		if got.UID != txnUID {
+			t.Error("should return the UID of the transfer", cross, got.UID)
+		}
+
+		if got.Balance == nil || *got.Balance != (Amount{Currency: "GBP", MinorUnits: 6050}) {
+			t.Error("should return the resulting balance of the savings goal", cross, got.Balance)
+		}
+	}
+}
+
+// TestTransferFromSavingsGoalWithUID_InvalidUID confirms that the client rejects a transfer
+// UID that is not a UUID without calling the API.
+func TestTransferFromSavingsGoalWithUID_InvalidUID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/savings-goals/", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not call the API", cross)
+	})
+
+	_, _, err := client.TransferFromSavingsGoalWithUID(context.Background(), "d8770f9d-4ee9-4cc1-86e1-83c26bcfcc4f", "123", Amount{Currency: "GBP", MinorUnits: 1050})
+	checkHasError(t, err)
+}
+
+// TestTransferFromSavingsGoal_Unsuccessful confirms that the client returns an error when the
+// API reports that a transfer failed in an otherwise successful response.
+func TestTransferFromSavingsGoal_Unsuccessful(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	goalUID := "d8770f9d-4ee9-4cc1-86e1-83c26bcfcc4f"
+
+	mux.HandleFunc("/api/v1/savings-goals/", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"transferUid": "28dff346-dd48-426f-96df-d7f33d29c379", "success": false, "errors": [{"message": "INSUFFICIENT_FUNDS"}]}`)
+	})
+
+	tr, _, err := client.TransferFromSavingsGoalWithUID(context.Background(), goalUID, "", Amount{Currency: "GBP", MinorUnits: 1050})
+	checkHasError(t, err)
+
+	if tr == nil || tr.Success || tr.Balance != nil {
+		t.Error("should return the unsuccessful transfer result without a balance", cross, tr)
+	}
+
+	if err.Error() != "INSUFFICIENT_FUNDS" {
+		t.Error("should return the errors reported by the API", cross, err)
+	}
+}
+
+// TestCreateRecurringTransfer_Unsuccessful confirms that the client returns an error when the
+// API reports that a recurring transfer could not be set up.
+func TestCreateRecurringTransfer_Unsuccessful(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/savings-goals/", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodPut)
+		fmt.Fprint(w, `{"success": false, "errors": [{"message": "INVALID_START_DATE"}]}`)
+	})
+
+	rtrReq := RecurringTransferRequest{
+		Amount:         Amount{Currency: "GBP", MinorUnits: 1234},
+		RecurrenceRule: RecurrenceRule{StartDate: "2017-09-23", Frequency: "DAILY", Interval: 2, Count: 4},
+	}
+
+	_, _, err := client.CreateRecurringTransfer(context.Background(), "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", rtrReq)
+	checkHasError(t, err)
+
+	if err.Error() != "INVALID_START_DATE" {
+		t.Error("should return the errors reported by the API", cross, err)
+	}
+}
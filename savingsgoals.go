@@ -0,0 +1,270 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// SavingsGoalState describes the lifecycle state of a savings goal
+type SavingsGoalState string
+
+// States of a savings goal
+const (
+	SavingsGoalCreating  SavingsGoalState = "CREATING"
+	SavingsGoalActive    SavingsGoalState = "ACTIVE"
+	SavingsGoalArchiving SavingsGoalState = "ARCHIVING"
+	SavingsGoalArchived  SavingsGoalState = "ARCHIVED"
+	SavingsGoalRestoring SavingsGoalState = "RESTORING"
+	SavingsGoalPending   SavingsGoalState = "PENDING"
+)
+
+// AccountSavingsGoal is a savings goal held within an individual account
+type AccountSavingsGoal struct {
+	UID             string           `json:"savingsGoalUid"`
+	Name            string           `json:"name"`
+	Target          Amount           `json:"target"`
+	TotalSaved      Amount           `json:"totalSaved"`
+	SavedPercentage int32            `json:"savedPercentage"`
+	State           SavingsGoalState `json:"state"`
+}
+
+type accountSavingsGoals struct {
+	SavingsGoals []AccountSavingsGoal `json:"savingsGoalList"`
+}
+
+// TransferResult is the result of a transfer into or out of a savings goal or space.
+//
+// Each transfer is identified by a UID chosen by the client. The WithUID variants of the
+// transfer methods take the UID from the caller, generating one if it is empty. Retrying a
+// transfer with the same UID does not move the money again, so it is safe to retry after a
+// timeout. On success the result of these methods includes the Balance of the goal or
+// space, which is left unset if it cannot be retrieved as the transfer has already
+// completed.
+type TransferResult struct {
+	UID     string        `json:"transferUid"` // Unique identifier for the transfer
+	Success bool          `json:"success"`     // True if the transfer completed successfully
+	Errors  []ErrorDetail `json:"errors"`      // List of errors if the transfer failed
+	Balance *Amount       `json:"-"`           // Total held in the goal or space once the transfer completed, if known
+}
+
+// AccountSavingsGoals returns the savings goals held within an account.
+//
+// Note: AccountSavingsGoals uses the v2 API which is still under active development.
+func (c *Client) AccountSavingsGoals(ctx context.Context, act string) ([]AccountSavingsGoal, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account/"+act+"/savings-goals", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var goals accountSavingsGoals
+	resp, err := c.Do(ctx, req, &goals)
+	if err != nil {
+		return nil, resp, err
+	}
+	return goals.SavingsGoals, resp, nil
+}
+
+// AccountSavingsGoal returns an individual savings goal held within an account.
+//
+// Note: AccountSavingsGoal uses the v2 API which is still under active development.
+func (c *Client) AccountSavingsGoal(ctx context.Context, act, uid string) (*AccountSavingsGoal, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account/"+act+"/savings-goals/"+uid, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var goal *AccountSavingsGoal
+	resp, err := c.Do(ctx, req, &goal)
+	return goal, resp, err
+}
+
+// CreateAccountSavingsGoal creates a savings goal within an account and returns its UID. An
+// error is returned if the API reports that the goal could not be created.
+//
+// Note: CreateAccountSavingsGoal uses the v2 API which is still under active development.
+func (c *Client) CreateAccountSavingsGoal(ctx context.Context, act string, sgReq SavingsGoalRequest) (string, *http.Response, error) {
+	req, err := c.NewRequest("PUT", "/api/v2/account/"+act+"/savings-goals", sgReq)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return c.doSavingsGoal(ctx, req)
+}
+
+// UpdateAccountSavingsGoal updates the name, target and photo of a savings goal held
+// within an account. An error is returned if the API reports that the goal could not be
+// updated.
+//
+// Note: UpdateAccountSavingsGoal uses the v2 API which is still under active development.
+func (c *Client) UpdateAccountSavingsGoal(ctx context.Context, act, uid string, sgReq SavingsGoalRequest) (*http.Response, error) {
+	req, err := c.NewRequest("PUT", "/api/v2/account/"+act+"/savings-goals/"+uid, sgReq)
+	if err != nil {
+		return nil, err
+	}
+
+	_, resp, err := c.doSavingsGoal(ctx, req)
+	return resp, err
+}
+
+// doSavingsGoal sends a request to create or update a savings goal and checks the result.
+func (c *Client) doSavingsGoal(ctx context.Context, req *http.Request) (string, *http.Response, error) {
+	var sgResp *savingsGoalResponse
+	resp, err := c.Do(ctx, req, &sgResp)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if sgResp == nil {
+		return "", resp, errors.New("no savings goal response returned")
+	}
+
+	if !sgResp.Success {
+		return sgResp.UID, resp, detailErrors(sgResp.Errors)
+	}
+	return sgResp.UID, resp, nil
+}
+
+// DeleteAccountSavingsGoal deletes a savings goal held within an account.
+//
+// Note: DeleteAccountSavingsGoal uses the v2 API which is still under active development.
+func (c *Client) DeleteAccountSavingsGoal(ctx context.Context, act, uid string) (*http.Response, error) {
+	req, err := c.NewRequest("DELETE", "/api/v2/account/"+act+"/savings-goals/"+uid, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(ctx, req, nil)
+}
+
+// AccountSavingsGoalPhoto returns the photo for a savings goal held within an account.
+//
+// Note: AccountSavingsGoalPhoto uses the v2 API which is still under active development.
+func (c *Client) AccountSavingsGoalPhoto(ctx context.Context, act, uid string) (*Photo, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account/"+act+"/savings-goals/"+uid+"/photo", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var photo *Photo
+	resp, err := c.Do(ctx, req, &photo)
+	return photo, resp, err
+}
+
+// TransferToAccountSavingsGoal transfers money from an account into one of its savings
+// goals. Each call is made with a new transfer UID; use TransferToAccountSavingsGoalWithUID
+// if the transfer may need to be retried. The result is returned along with an error if the
+// API reports that the transfer failed.
+//
+// Note: TransferToAccountSavingsGoal uses the v2 API which is still under active
+// development.
+func (c *Client) TransferToAccountSavingsGoal(ctx context.Context, act, goalUID string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.TransferToAccountSavingsGoalWithUID(ctx, act, goalUID, "", a)
+}
+
+// TransferToAccountSavingsGoalWithUID transfers money from an account into one of its
+// savings goals using the transfer UID provided by the caller, see TransferResult. The
+// result is returned along with an error if the API reports that the transfer failed.
+//
+// Note: TransferToAccountSavingsGoalWithUID uses the v2 API which is still under active
+// development.
+func (c *Client) TransferToAccountSavingsGoalWithUID(ctx context.Context, act, goalUID, txnUID string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.transferWithBalance(ctx, "/api/v2/account/"+act+"/savings-goals/"+goalUID+"/add-money", txnUID, a, c.accountSavingsGoalTotal(act, goalUID))
+}
+
+// TransferFromAccountSavingsGoal transfers money out of a savings goal into the account
+// that holds it. Each call is made with a new transfer UID; use
+// TransferFromAccountSavingsGoalWithUID if the transfer may need to be retried. The result
+// is returned along with an error if the API reports that the transfer failed.
+//
+// Note: TransferFromAccountSavingsGoal uses the v2 API which is still under active
+// development.
+func (c *Client) TransferFromAccountSavingsGoal(ctx context.Context, act, goalUID string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.TransferFromAccountSavingsGoalWithUID(ctx, act, goalUID, "", a)
+}
+
+// TransferFromAccountSavingsGoalWithUID transfers money out of a savings goal into the
+// account that holds it using the transfer UID provided by the caller, see TransferResult.
+// The result is returned along with an error if the API reports that the transfer failed.
+//
+// Note: TransferFromAccountSavingsGoalWithUID uses the v2 API which is still under active
+// development.
+func (c *Client) TransferFromAccountSavingsGoalWithUID(ctx context.Context, act, goalUID, txnUID string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.transferWithBalance(ctx, "/api/v2/account/"+act+"/savings-goals/"+goalUID+"/withdraw-money", txnUID, a, c.accountSavingsGoalTotal(act, goalUID))
+}
+
+// accountSavingsGoalTotal returns a function that retrieves the total saved in a savings
+// goal.
+func (c *Client) accountSavingsGoalTotal(act, goalUID string) func(context.Context) (*Amount, error) {
+	return func(ctx context.Context) (*Amount, error) {
+		goal, _, err := c.AccountSavingsGoal(ctx, act, goalUID)
+		if err != nil || goal == nil {
+			return nil, err
+		}
+		return &goal.TotalSaved, nil
+	}
+}
+
+// transferWithBalance makes a transfer using the endpoint at base and, if successful, calls
+// balance to retrieve the resulting balance of the goal or space. The balance is left unset
+// if it cannot be retrieved as the transfer itself has already completed.
+func (c *Client) transferWithBalance(ctx context.Context, base, txnUID string, a Amount, balance func(context.Context) (*Amount, error)) (*TransferResult, *http.Response, error) {
+	tr, resp, err := c.transfer(ctx, base, txnUID, a)
+	if err != nil {
+		return tr, resp, err
+	}
+
+	if bal, err := balance(ctx); err == nil {
+		tr.Balance = bal
+	}
+	return tr, resp, nil
+}
+
+// transfer moves an amount using the endpoint at base, which is suffixed with the transfer
+// UID, and checks the result. A new transfer UID is generated if txnUID is empty.
+func (c *Client) transfer(ctx context.Context, base, txnUID string, a Amount) (*TransferResult, *http.Response, error) {
+	if txnUID == "" {
+		u, err := uuid.NewRandom()
+		if err != nil {
+			return nil, nil, err
+		}
+		txnUID = u.String()
+	} else if _, err := uuid.Parse(txnUID); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid transfer UID")
+	}
+
+	req, err := c.NewRequest("PUT", base+"/"+txnUID, topUpRequest{Amount: a})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tr *TransferResult
+	resp, err := c.Do(ctx, req, &tr)
+	if err != nil {
+		return tr, resp, err
+	}
+
+	if tr == nil {
+		return nil, resp, errors.New("no transfer result returned")
+	}
+
+	if !tr.Success {
+		return tr, resp, detailErrors(tr.Errors)
+	}
+	return tr, resp, nil
+}
+
+// detailErrors converts the error details in an API response into Errors.
+func detailErrors(ds []ErrorDetail) error {
+	if len(ds) == 0 {
+		return errors.New("request was not successful: no additional error information available")
+	}
+
+	e := make(Errors, len(ds))
+	for i, d := range ds {
+		e[i] = d.Message
+	}
+	return e
+}
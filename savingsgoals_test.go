@@ -0,0 +1,381 @@
+package starling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var accountSavingsGoalTC = []struct {
+	name string
+	mock string
+}{
+	{
+		name: "active savings goal",
+		mock: `{
+			"savingsGoalUid": "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b",
+			"name": "Trip to Paris",
+			"target": {
+				"currency": "GBP",
+				"minorUnits": 11223344
+			},
+			"totalSaved": {
+				"currency": "GBP",
+				"minorUnits": 5611672
+			},
+			"savedPercentage": 50,
+			"state": "ACTIVE"
+		}`,
+	},
+	{
+		name: "restoring savings goal without a target",
+		mock: `{
+			"savingsGoalUid": "d8770f9d-4ee9-4cc1-86e1-83c26bcfcc4f",
+			"name": "Rainy day",
+			"totalSaved": {
+				"currency": "GBP",
+				"minorUnits": 1050
+			},
+			"state": "RESTORING"
+		}`,
+	},
+}
+
+// TestAccountSavingsGoals confirms that the client is able to query the savings goals held
+// within an account.
+func TestAccountSavingsGoals(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	mock := `{"savingsGoalList": [` + accountSavingsGoalTC[0].mock + `,` + accountSavingsGoalTC[1].mock + `]}`
+
+	mux.HandleFunc("/api/v2/account/"+act+"/savings-goals", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprint(w, mock)
+	})
+
+	got, _, err := client.AccountSavingsGoals(context.Background(), act)
+	checkNoError(t, err)
+
+	want := &accountSavingsGoals{}
+	json.Unmarshal([]byte(mock), want)
+
+	if !reflect.DeepEqual(got, want.SavingsGoals) {
+		t.Error("should return a list of savings goals matching the mock response", cross)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("should return two savings goals %s %d", cross, len(got))
+	}
+
+	if got[1].State != SavingsGoalRestoring {
+		t.Error("should return the state of each savings goal", cross, got[1].State)
+	}
+}
+
+// TestAccountSavingsGoal confirms that the client is able to query a single savings goal
+// held within an account.
+func TestAccountSavingsGoal(t *testing.T) {
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+
+	for _, tc := range accountSavingsGoalTC {
+		t.Run(tc.name, func(st *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			want := &AccountSavingsGoal{}
+			json.Unmarshal([]byte(tc.mock), want)
+
+			mux.HandleFunc("/api/v2/account/"+act+"/savings-goals/"+want.UID, func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(st, r, "GET")
+				fmt.Fprint(w, tc.mock)
+			})
+
+			got, _, err := client.AccountSavingsGoal(context.Background(), act, want.UID)
+			checkNoError(st, err)
+
+			if !reflect.DeepEqual(got, want) {
+				st.Error("should return a savings goal matching the mock response", cross)
+			}
+		})
+	}
+}
+
+// TestCreateAccountSavingsGoal confirms that the client is able to create a savings goal
+// within an account and return the UID assigned to it.
+func TestCreateAccountSavingsGoal(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	mockReq := SavingsGoalRequest{
+		Name:     "Trip to Paris",
+		Currency: "GBP",
+		Target:   Amount{Currency: "GBP", MinorUnits: 10000},
+	}
+	mockResp := `{"savingsGoalUid": "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b", "success": true, "errors": []}`
+
+	mux.HandleFunc("/api/v2/account/"+act+"/savings-goals", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+
+		var sg = SavingsGoalRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&sg); err != nil {
+			t.Fatal("should send a request that the API can parse", cross, err)
+		}
+
+		if !reflect.DeepEqual(mockReq, sg) {
+			t.Error("should send a savings goal that matches the mock", cross)
+		}
+
+		fmt.Fprint(w, mockResp)
+	})
+
+	uid, _, err := client.CreateAccountSavingsGoal(context.Background(), act, mockReq)
+	checkNoError(t, err)
+
+	if uid != "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b" {
+		t.Error("should return the UID assigned to the savings goal", cross, uid)
+	}
+}
+
+// TestCreateAccountSavingsGoal_Unsuccessful confirms that the client returns an error when
+// the API reports that a savings goal could not be created.
+func TestCreateAccountSavingsGoal_Unsuccessful(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	mockResp := `{"success": false, "errors": [{"message": "NAME_TOO_LONG"}]}`
+
+	mux.HandleFunc("/api/v2/account/"+act+"/savings-goals", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+		fmt.Fprint(w, mockResp)
+	})
+
+	_, _, err := client.CreateAccountSavingsGoal(context.Background(), act, SavingsGoalRequest{Name: "Trip to Paris"})
+	checkHasError(t, err)
+
+	if err.Error() != "NAME_TOO_LONG" {
+		t.Error("should return the errors reported by the API", cross, err)
+	}
+}
+
+// TestUpdateAccountSavingsGoal confirms that the client is able to update a savings goal
+// held within an account.
+func TestUpdateAccountSavingsGoal(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	uid := "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b"
+	mockReq := SavingsGoalRequest{
+		Name:               "Trip to Rome",
+		Currency:           "GBP",
+		Target:             Amount{Currency: "GBP", MinorUnits: 20000},
+		Base64EncodedPhoto: "aGVsbG8=",
+	}
+
+	mux.HandleFunc("/api/v2/account/"+act+"/savings-goals/"+uid, func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+
+		var sg = SavingsGoalRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&sg); err != nil {
+			t.Fatal("should send a request that the API can parse", cross, err)
+		}
+
+		if !reflect.DeepEqual(mockReq, sg) {
+			t.Error("should send a savings goal that matches the mock", cross)
+		}
+
+		fmt.Fprintf(w, `{"savingsGoalUid": "%s", "success": true, "errors": []}`, uid)
+	})
+
+	resp, err := client.UpdateAccountSavingsGoal(context.Background(), act, uid, mockReq)
+	checkNoError(t, err)
+	checkStatus(t, resp, http.StatusOK)
+}
+
+// TestDeleteAccountSavingsGoal confirms that the client is able to delete a savings goal
+// held within an account.
+func TestDeleteAccountSavingsGoal(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	uid := "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b"
+
+	mux.HandleFunc("/api/v2/account/"+act+"/savings-goals/"+uid, func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.DeleteAccountSavingsGoal(context.Background(), act, uid)
+	checkNoError(t, err)
+	checkStatus(t, resp, http.StatusNoContent)
+}
+
+// TestAccountSavingsGoalPhoto confirms that the client is able to retrieve the photo for a
+// savings goal held within an account.
+func TestAccountSavingsGoalPhoto(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	uid := "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b"
+
+	mux.HandleFunc("/api/v2/account/"+act+"/savings-goals/"+uid+"/photo", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprint(w, `{"base64EncodedPhoto": "aGVsbG8="}`)
+	})
+
+	got, _, err := client.AccountSavingsGoalPhoto(context.Background(), act, uid)
+	checkNoError(t, err)
+
+	if got == nil || got.Base64EncodedPhoto != "aGVsbG8=" {
+		t.Error("should return the photo matching the mock response", cross, got)
+	}
+}
+
+var accountSavingsGoalTransferTC = []struct {
+	name      string
+	direction string
+	transfer  func(*Client, context.Context, string, string, Amount) (*TransferResult, *http.Response, error)
+}{
+	{
+		name:      "transfer to savings goal",
+		direction: "add-money",
+		transfer:  (*Client).TransferToAccountSavingsGoal,
+	},
+	{
+		name:      "transfer from savings goal",
+		direction: "withdraw-money",
+		transfer:  (*Client).TransferFromAccountSavingsGoal,
+	},
+}
+
+// TestAccountSavingsGoalTransfer confirms that the client is able to move money into and out
+// of a savings goal held within an account and return the typed result.
+func TestAccountSavingsGoalTransfer(t *testing.T) {
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	goalUID := "d8770f9d-4ee9-4cc1-86e1-83c26bcfcc4f"
+	mockAmount := Amount{Currency: "GBP", MinorUnits: 1050}
+	mockResp := `{"transferUid": "28dff346-dd48-426f-96df-d7f33d29c379", "success": true, "errors": []}`
+
+	for _, tc := range accountSavingsGoalTransferTC {
+		t.Run(tc.name, func(st *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/api/v2/account/"+act+"/savings-goals/"+goalUID+"/", func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(st, r, "PUT")
+
+				var tu = topUpRequest{}
+				if err := json.NewDecoder(r.Body).Decode(&tu); err != nil {
+					st.Fatal("should send a request that the API can parse", cross, err)
+				}
+
+				if !reflect.DeepEqual(topUpRequest{Amount: mockAmount}, tu) {
+					st.Error("should send an amount that matches the mock", cross)
+				}
+
+				if resource := path.Base(path.Dir(r.URL.Path)); resource != tc.direction {
+					st.Error("should make a request to "+tc.direction, cross, resource)
+				}
+
+				if _, err := uuid.Parse(path.Base(r.URL.Path)); err != nil {
+					st.Error("should send the transfer with a valid UID", cross, err)
+				}
+
+				fmt.Fprint(w, mockResp)
+			})
+
+			mux.HandleFunc("/api/v2/account/"+act+"/savings-goals/"+goalUID, func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(st, r, "GET")
+				fmt.Fprintf(w, `{"savingsGoalUid": "%s", "totalSaved": {"currency": "GBP", "minorUnits": 6050}}`, goalUID)
+			})
+
+			got, _, err := tc.transfer(client, context.Background(), act, goalUID, mockAmount)
+			checkNoError(st, err)
+
+			want := &TransferResult{Balance: &Amount{Currency: "GBP", MinorUnits: 6050}}
+			json.Unmarshal([]byte(mockResp), want)
+
+			if !reflect.DeepEqual(got, want) {
+				st.Error("should return a transfer result matching the mock response", cross, got)
+			}
+		})
+	}
+}
+
+// TestTransferToAccountSavingsGoalWithUID confirms that the client sends the transfer UID
+// supplied by the caller and returns the resulting balance of the savings goal.
+func TestTransferToAccountSavingsGoalWithUID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	goalUID := "d8770f9d-4ee9-4cc1-86e1-83c26bcfcc4f"
+	txnUID := "28dff346-dd48-426f-96df-d7f33d29c379"
+
+	mux.HandleFunc("/api/v2/account/"+act+"/savings-goals/"+goalUID+"/add-money/", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+
+		if reqUID := path.Base(r.URL.Path); reqUID != txnUID {
+			t.Error("should send the transfer UID supplied by the caller", cross, reqUID)
+		}
+
+		fmt.Fprintf(w, `{"transferUid": "%s", "success": true, "errors": []}`, txnUID)
+	})
+
+	mux.HandleFunc("/api/v2/account/"+act+"/savings-goals/"+goalUID, func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprintf(w, `{"savingsGoalUid": "%s", "totalSaved": {"currency": "GBP", "minorUnits": 6050}}`, goalUID)
+	})
+
+	got, _, err := client.TransferToAccountSavingsGoalWithUID(context.Background(), act, goalUID, txnUID, Amount{Currency: "GBP", MinorUnits: 1050})
+	checkNoError(t, err)
+
+	if got.UID != txnUID {
+		t.Error("should return the UID of the transfer", cross, got.UID)
+	}
+
+	if got.Balance == nil || *got.Balance != (Amount{Currency: "GBP", MinorUnits: 6050}) {
+		t.Error("should return the resulting balance of the savings goal", cross, got.Balance)
+	}
+
+	_, _, err = client.TransferFromAccountSavingsGoalWithUID(context.Background(), act, goalUID, "123", Amount{Currency: "GBP", MinorUnits: 1050})
+	checkHasError(t, err)
+}
+
+// TestAccountSavingsGoalTransfer_Unsuccessful confirms that the client returns the result
+// along with an error when the API reports that a transfer failed.
+func TestAccountSavingsGoalTransfer_Unsuccessful(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	goalUID := "d8770f9d-4ee9-4cc1-86e1-83c26bcfcc4f"
+
+	mux.HandleFunc("/api/v2/account/"+act+"/savings-goals/"+goalUID+"/", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+		fmt.Fprint(w, `{"success": false, "errors": [{"message": "INSUFFICIENT_FUNDS"}]}`)
+	})
+
+	got, _, err := client.TransferFromAccountSavingsGoal(context.Background(), act, goalUID, Amount{Currency: "GBP", MinorUnits: 1050})
+	checkHasError(t, err)
+
+	if got == nil || got.Success {
+		t.Fatal("should return the unsuccessful transfer result", cross, got)
+	}
+
+	if errs, ok := err.(Errors); !ok || len(errs) != 1 || errs[0] != "INSUFFICIENT_FUNDS" {
+		t.Error("should return the errors reported by the API", cross, err)
+	}
+}
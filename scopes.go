@@ -0,0 +1,275 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// route maps an API request to the client method that makes it and the scopes the
+// token must have been granted for the request to succeed.
+type route struct {
+	method string
+	path   string // "*" matches any single path segment
+	op     string
+	scopes []string
+}
+
+// routes lists the requests made by the client. More specific paths must be listed
+// before wildcard paths that would also match them.
+var routes = []route{
+	{"GET", "/api/v1/me", "CurrentUser", nil},
+
+	{"GET", "/api/v1/accounts", "Account", []string{"account:read"}},
+	{"GET", "/api/v1/accounts/balance", "AccountBalance", []string{"balance:read"}},
+	{"GET", "/api/v2/accounts", "Accounts", []string{"account:read"}},
+	{"GET", "/api/v2/accounts/*/identifiers", "AccountID", []string{"account-identifier:read"}},
+	{"GET", "/api/v2/accounts/*/balance", "AccountBalances", []string{"balance:read"}},
+	{"GET", "/api/v2/accounts/*/confirmation-of-funds", "ConfirmFunds", []string{"confirmation-of-funds:read"}},
+	{"GET", "/api/v2/account-holder", "AccountHolder", []string{"account-holder-type:read"}},
+	{"GET", "/api/v2/account-holder/name", "AccountHolderName", []string{"account-holder-name:read"}},
+	{"GET", "/api/v2/account-holder/individual", "Individual", []string{"customer:read"}},
+	{"GET", "/api/v2/account-holder/joint", "JointAccountHolders", []string{"customer:read"}},
+	{"GET", "/api/v2/account-holder/sole-trader", "SoleTrader", []string{"customer:read"}},
+	{"GET", "/api/v2/account-holder/business", "Business", []string{"customer:read"}},
+	{"GET", "/api/v2/account-holder/business/registered-address", "BusinessRegisteredAddress", []string{"address:read"}},
+	{"GET", "/api/v2/account-holder/business/correspondence-address", "BusinessCorrespondenceAddress", []string{"address:read"}},
+	{"GET", "/api/v2/accounts/*/spending-insights/spending-category", "SpendingByCategory", []string{"transaction:read"}},
+	{"GET", "/api/v2/accounts/*/spending-insights/counter-party", "SpendingByCounterParty", []string{"transaction:read"}},
+	{"GET", "/api/v2/accounts/*/spending-insights/country", "SpendingByCountry", []string{"transaction:read"}},
+
+	{"GET", "/api/v1/addresses", "AddressHistory", []string{"address:read"}},
+	{"GET", "/api/v1/cards", "Card", []string{"card:read"}},
+	{"GET", "/api/v2/cards", "Cards", []string{"card:read"}},
+	{"PUT", "/api/v2/cards/*/controls/enabled", "LockCard", []string{"card-control:edit"}}, // and UnlockCard
+	{"PUT", "/api/v2/cards/*/controls/*", "SetCardChannel", []string{"card-control:edit"}},
+	{"GET", "/api/v1/customers", "Customer", []string{"customer:read"}},
+
+	{"GET", "/api/v1/contacts", "Contacts", []string{"payee:read"}},
+	{"POST", "/api/v1/contacts", "CreateContactAccount", []string{"payee:create"}},
+	{"GET", "/api/v1/contacts/*", "Contact", []string{"payee:read"}},
+	{"DELETE", "/api/v1/contacts/*", "DeleteContact", []string{"payee:delete"}},
+	{"GET", "/api/v1/contacts/*/accounts", "ContactAccounts", []string{"payee:read"}},
+	{"GET", "/api/v1/contacts/*/accounts/*", "ContactAccount", []string{"payee:read"}},
+
+	{"GET", "/api/v2/payees", "Payees", []string{"payee:read"}},
+	{"PUT", "/api/v2/payees", "CreatePayee", []string{"payee:create"}},
+	{"PUT", "/api/v2/payees/*", "UpdatePayee", []string{"payee:edit"}},
+	{"DELETE", "/api/v2/payees/*", "DeletePayee", []string{"payee:delete"}},
+	{"GET", "/api/v2/payees/*/image", "PayeeImage", []string{"payee-image:read"}},
+	{"PUT", "/api/v2/payees/*/account", "CreatePayeeAccount", []string{"payee:create"}},
+	{"PUT", "/api/v2/payees/*/account/*", "UpdatePayeeAccount", []string{"payee:edit"}},
+	{"DELETE", "/api/v2/payees/*/account/*", "DeletePayeeAccount", []string{"payee:delete"}},
+	{"GET", "/api/v2/payees/*/account/*/scheduled-payments", "PayeeScheduledPayments", []string{"scheduled-payment:read"}},
+	{"GET", "/api/v2/payees/*/account/*/payments", "PayeePayments", []string{"payee:read"}},
+
+	{"GET", "/api/v1/direct-debit/mandates", "DirectDebitMandates", []string{"mandate:read"}},
+	{"GET", "/api/v1/direct-debit/mandates/*", "DirectDebitMandate", []string{"mandate:read"}},
+	{"DELETE", "/api/v1/direct-debit/mandates/*", "DeleteDirectDebitMandate", []string{"mandate:delete"}},
+
+	{"GET", "/api/v2/feed/account/*/category/*", "Feed", []string{"transaction:read"}},
+	{"GET", "/api/v2/feed/account/*/category/*/*", "FeedItem", []string{"transaction:read"}},
+	{"GET", "/api/v2/feed/account/*/round-up", "RoundUp", []string{"savings-goal-transfer:read"}},
+	{"PUT", "/api/v2/feed/account/*/round-up", "ActivateRoundUp", []string{"savings-goal-transfer:create"}},
+	{"DELETE", "/api/v2/feed/account/*/round-up", "DeactivateRoundUp", []string{"savings-goal-transfer:delete"}},
+
+	{"GET", "/api/v1/merchants/*", "Merchant", []string{"merchant:read"}},
+	{"GET", "/api/v1/merchants/*/locations/*", "MerchantLocation", []string{"merchant:read"}},
+
+	{"POST", "/api/v1/payments/local", "MakeLocalPayment", []string{"pay-local:create"}},
+	{"GET", "/api/v1/payments/scheduled", "ScheduledPayments", []string{"scheduled-payment:read"}},
+	{"POST", "/api/v1/payments/scheduled", "CreateScheduledPayment", []string{"pay-local:create"}},
+
+	{"GET", "/api/v1/savings-goals", "SavingsGoals", []string{"savings-goal:read"}},
+	{"GET", "/api/v1/savings-goals/*", "SavingsGoal", []string{"savings-goal:read"}},
+	{"PUT", "/api/v1/savings-goals/*", "CreateSavingsGoal", []string{"savings-goal:create"}},
+	{"DELETE", "/api/v1/savings-goals/*", "DeleteSavingsGoal", []string{"savings-goal:delete"}},
+	{"GET", "/api/v1/savings-goals/*/photo", "SavingsGoalPhoto", []string{"savings-goal:read"}},
+	{"PUT", "/api/v1/savings-goals/*/add-money/*", "TransferToSavingsGoal", []string{"savings-goal-transfer:create"}},
+	{"PUT", "/api/v1/savings-goals/*/withdraw-money/*", "TransferFromSavingsGoal", []string{"savings-goal-transfer:create"}},
+	{"GET", "/api/v1/savings-goals/*/recurring-transfer", "RecurringTransfer", []string{"savings-goal-transfer:read"}},
+	{"PUT", "/api/v1/savings-goals/*/recurring-transfer", "CreateRecurringTransfer", []string{"savings-goal-transfer:create"}},
+	{"DELETE", "/api/v1/savings-goals/*/recurring-transfer", "DeleteRecurringTransfer", []string{"savings-goal-transfer:delete"}},
+
+	{"GET", "/api/v2/account/*/savings-goals", "AccountSavingsGoals", []string{"savings-goal:read"}},
+	{"PUT", "/api/v2/account/*/savings-goals", "CreateAccountSavingsGoal", []string{"savings-goal:create"}},
+	{"GET", "/api/v2/account/*/savings-goals/*", "AccountSavingsGoal", []string{"savings-goal:read"}},
+	{"PUT", "/api/v2/account/*/savings-goals/*", "UpdateAccountSavingsGoal", []string{"savings-goal:create"}},
+	{"DELETE", "/api/v2/account/*/savings-goals/*", "DeleteAccountSavingsGoal", []string{"savings-goal:delete"}},
+	{"GET", "/api/v2/account/*/savings-goals/*/photo", "AccountSavingsGoalPhoto", []string{"savings-goal:read"}},
+	{"PUT", "/api/v2/account/*/savings-goals/*/add-money/*", "TransferToAccountSavingsGoal", []string{"savings-goal-transfer:create"}},
+	{"PUT", "/api/v2/account/*/savings-goals/*/withdraw-money/*", "TransferFromAccountSavingsGoal", []string{"savings-goal-transfer:create"}},
+
+	{"GET", "/api/v2/account/*/spaces", "Spaces", []string{"space:read"}},
+	{"GET", "/api/v2/account/*/spaces/spending/*", "SpendingSpace", []string{"space:read"}},
+	{"PUT", "/api/v2/account/*/spaces/spending/*/add-money/*", "TransferToSpendingSpace", []string{"space:edit"}},
+	{"PUT", "/api/v2/account/*/spaces/spending/*/withdraw-money/*", "TransferFromSpendingSpace", []string{"space:edit"}},
+
+	{"GET", "/api/v1/transactions", "Transactions", []string{"transaction:read"}},
+	{"GET", "/api/v1/transactions/direct-debit", "DDTransactions", []string{"transaction:read"}},
+	{"GET", "/api/v1/transactions/direct-debit/*", "DDTransaction", []string{"transaction:read"}},
+	{"PUT", "/api/v1/transactions/direct-debit/*", "SetDDSpendingCategory", []string{"transaction:edit"}},
+	{"GET", "/api/v1/transactions/fps/in", "FPSTransactionsIn", []string{"transaction:read"}},
+	{"GET", "/api/v1/transactions/fps/in/*", "FPSTransactionIn", []string{"transaction:read"}},
+	{"GET", "/api/v1/transactions/fps/out", "FPSTransactionsOut", []string{"transaction:read"}},
+	{"GET", "/api/v1/transactions/fps/out/*", "FPSTransactionOut", []string{"transaction:read"}},
+	{"GET", "/api/v1/transactions/mastercard", "MastercardTransactions", []string{"transaction:read"}},
+	{"GET", "/api/v1/transactions/mastercard/*", "MastercardTransaction", []string{"transaction:read"}},
+	{"PUT", "/api/v1/transactions/mastercard/*", "SetMastercardSpendingCategory", []string{"transaction:edit"}},
+	{"POST", "/api/v1/transactions/mastercard/*/receipt", "CreateReceipt", []string{"receipt:write"}},
+	{"GET", "/api/v1/transactions/*", "Transaction", []string{"transaction:read"}},
+}
+
+// match reports whether the route matches the request method and path.
+func (r route) match(method, path string) bool {
+	if r.method != method {
+		return false
+	}
+
+	want := strings.Split(strings.Trim(r.path, "/"), "/")
+	got := strings.Split(strings.Trim(path, "/"), "/")
+	if len(want) != len(got) {
+		return false
+	}
+
+	for i := range want {
+		if want[i] != "*" && want[i] != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup returns the first route matching the request method and path.
+func lookup(method, path string) (route, bool) {
+	for _, r := range routes {
+		if r.match(method, path) {
+			return r, true
+		}
+	}
+	return route{}, false
+}
+
+// route returns the route matching a request made by the client. The path of the client
+// base URL is removed first so that requests to an API served beneath a path prefix are
+// still recognised.
+func (c *Client) route(req *http.Request) (route, bool) {
+	p := req.URL.Path
+	if base := strings.TrimSuffix(c.baseURL.Path, "/"); base != "" && strings.HasPrefix(p, base+"/") {
+		p = strings.TrimPrefix(p, base)
+	}
+	return lookup(req.Method, p)
+}
+
+// opKey is the context key under which Do records the client method that made a request,
+// so that transports do not need to know the base URL to recognise the request.
+type opKey struct{}
+
+// requestOp returns the client method that made a request, as recorded by Do, falling back
+// to matching the request path for requests not sent through a client.
+func requestOp(req *http.Request) (string, bool) {
+	if op, ok := req.Context().Value(opKey{}).(string); ok {
+		return op, true
+	}
+
+	r, ok := lookup(req.Method, req.URL.Path)
+	return r.op, ok
+}
+
+// RequiredScopes returns the scopes a token must have been granted to call the named
+// client method, e.g. "MakeLocalPayment". Nil is returned if the method is unknown or
+// requires no scopes.
+func RequiredScopes(op string) []string {
+	for _, r := range routes {
+		if r.op == op {
+			return r.scopes
+		}
+	}
+	return nil
+}
+
+// Scopes returns the scopes granted to the token used by the client. The scopes are
+// retrieved using CurrentUser the first time they are needed and cached for the lifetime
+// of the client, or until the token is refreshed.
+func (c *Client) Scopes(ctx context.Context) ([]string, error) {
+	c.scopesMu.Lock()
+	scopes := c.scopes
+	c.scopesMu.Unlock()
+
+	if scopes != nil {
+		return scopes, nil
+	}
+
+	// The lock is not held while the scopes are retrieved as a rejected request refreshes
+	// the token, which clears the cached scopes.
+	ident, _, err := c.CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes = []string{}
+	if ident != nil {
+		scopes = append(scopes, ident.Scopes...)
+	}
+
+	c.scopesMu.Lock()
+	c.scopes = scopes
+	c.scopesMu.Unlock()
+	return scopes, nil
+}
+
+// PermittedOperations returns the names of the client methods that the token has been
+// granted the scopes to call, in alphabetical order.
+func (c *Client) PermittedOperations(ctx context.Context) ([]string, error) {
+	granted, err := c.Scopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ops := []string{}
+	for _, r := range routes {
+		if len(missingScopes(r.scopes, granted)) == 0 {
+			ops = append(ops, r.op)
+		}
+	}
+	sort.Strings(ops)
+	return ops, nil
+}
+
+// authorize returns a ScopeError if the token does not have the scopes required by the
+// request. Requests that are not recognised are allowed.
+func (c *Client) authorize(ctx context.Context, req *http.Request) error {
+	r, ok := c.route(req)
+	if !ok || len(r.scopes) == 0 {
+		return nil
+	}
+
+	granted, err := c.Scopes(ctx)
+	if err != nil {
+		return err
+	}
+
+	if missing := missingScopes(r.scopes, granted); len(missing) != 0 {
+		return ScopeError{Operation: r.op, Missing: missing}
+	}
+	return nil
+}
+
+// missingScopes returns the required scopes that have not been granted.
+func missingScopes(required, granted []string) []string {
+	var missing []string
+	for _, req := range required {
+		found := false
+		for _, g := range granted {
+			if g == req {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, req)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,166 @@
+package starling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var lookupTC = []struct {
+	method string
+	path   string
+	op     string
+}{
+	{"GET", "/api/v1/me", "CurrentUser"},
+	{"GET", "/api/v1/transactions", "Transactions"},
+	{"GET", "/api/v1/transactions/direct-debit", "DDTransactions"},
+	{"GET", "/api/v1/transactions/1c2d3e4f", "Transaction"},
+	{"PUT", "/api/v1/transactions/mastercard/1c2d3e4f", "SetMastercardSpendingCategory"},
+	{"POST", "/api/v1/transactions/mastercard/1c2d3e4f/receipt", "CreateReceipt"},
+	{"PUT", "/api/v1/savings-goals/1c2d3e4f/add-money/5a6b7c8d", "TransferToSavingsGoal"},
+	{"GET", "/api/v2/feed/account/1c2d3e4f/category/5a6b7c8d/9e0f", "FeedItem"},
+	{"GET", "/api/v2/accounts/1c2d3e4f/spending-insights/country", "SpendingByCountry"},
+}
+
+func TestLookup(t *testing.T) {
+	for _, tc := range lookupTC {
+		t.Run(tc.method+" "+tc.path, func(st *testing.T) {
+			r, ok := lookup(tc.method, tc.path)
+			if !ok {
+				st.Fatal("should find a route for the request", cross)
+			}
+
+			if r.op != tc.op {
+				st.Error("should map the request to the correct operation", cross, r.op)
+			}
+		})
+	}
+
+	if _, ok := lookup("PATCH", "/api/v1/me"); ok {
+		t.Error("should not find a route for an unknown request", cross)
+	}
+}
+
+func TestRequiredScopes(t *testing.T) {
+	if got := RequiredScopes("MakeLocalPayment"); !reflect.DeepEqual(got, []string{"pay-local:create"}) {
+		t.Error("should return the scopes required by MakeLocalPayment", cross, got)
+	}
+
+	if got := RequiredScopes("SavingsGoals"); !reflect.DeepEqual(got, []string{"savings-goal:read"}) {
+		t.Error("should return the scopes required by SavingsGoals", cross, got)
+	}
+
+	if got := RequiredScopes("Unknown"); got != nil {
+		t.Error("should return no scopes for an unknown operation", cross, got)
+	}
+}
+
+func TestCheckScopes(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.checkScopes = true
+
+	meCalls := 0
+	mux.HandleFunc("/api/v1/me", func(w http.ResponseWriter, r *http.Request) {
+		meCalls++
+		fmt.Fprint(w, `{"customerUid": "c1", "authenticated": true, "scopes": ["savings-goal:read", "balance:read"]}`)
+	})
+
+	mux.HandleFunc("/api/v1/accounts/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"currency": "GBP"}`)
+	})
+
+	mux.HandleFunc("/api/v1/payments/local", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not call the API without the required scopes", cross)
+	})
+
+	_, _, err := client.AccountBalance(context.Background())
+	checkNoError(t, err)
+
+	resp, err := client.MakeLocalPayment(context.Background(), LocalPayment{})
+	checkHasError(t, err)
+
+	if resp != nil {
+		t.Error("should not return a response", cross)
+	}
+
+	se, ok := err.(ScopeError)
+	if !ok {
+		t.Fatal("should return a ScopeError", cross, err)
+	}
+
+	if se.Operation != "MakeLocalPayment" || !reflect.DeepEqual(se.Missing, []string{"pay-local:create"}) {
+		t.Error("should describe the missing scopes", cross, se)
+	}
+
+	if meCalls != 1 {
+		t.Error("should cache the token scopes", cross, meCalls)
+	}
+}
+
+func TestCheckScopesBasePath(t *testing.T) {
+	client, mux, serverURL, teardown := setup()
+	defer teardown()
+	client.checkScopes = true
+	client.baseURL, _ = url.Parse(serverURL + "/starling/")
+
+	mux.HandleFunc("/api/v1/me", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"customerUid": "c1", "authenticated": true, "scopes": ["balance:read"]}`)
+	})
+
+	mux.HandleFunc("/starling/api/v1/payments/local", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("should not call the API without the required scopes", cross)
+	})
+
+	req, err := client.NewRequest("POST", "api/v1/payments/local", LocalPayment{})
+	checkNoError(t, err)
+
+	_, err = client.Do(context.Background(), req, nil)
+	if se, ok := err.(ScopeError); !ok || se.Operation != "MakeLocalPayment" {
+		t.Error("should check the scopes of requests beneath the base URL path", cross, err)
+	}
+}
+
+func TestPermittedOperations(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v1/me", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"customerUid": "c1", "authenticated": true, "scopes": ["savings-goal:read"]}`)
+	})
+
+	got, err := client.PermittedOperations(context.Background())
+	checkNoError(t, err)
+
+	want := []string{"AccountSavingsGoal", "AccountSavingsGoalPhoto", "AccountSavingsGoals", "CurrentUser", "SavingsGoal", "SavingsGoalPhoto", "SavingsGoals"}
+	if !reflect.DeepEqual(got, want) {
+		t.Error("should list the operations permitted by the token scopes", cross, got)
+	}
+}
+
+func TestScopesCachesNoScopes(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	meCalls := 0
+	mux.HandleFunc("/api/v1/me", func(w http.ResponseWriter, r *http.Request) {
+		meCalls++
+		fmt.Fprint(w, `{"customerUid": "c1", "authenticated": true}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		got, err := client.Scopes(context.Background())
+		checkNoError(t, err)
+
+		if len(got) != 0 {
+			t.Error("should return no scopes", cross, got)
+		}
+	}
+
+	if meCalls != 1 {
+		t.Error("should cache a token without scopes", cross, meCalls)
+	}
+}
@@ -0,0 +1,176 @@
+package starling
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// signatureDateLayout is the layout of the Date header sent with signed requests
+const signatureDateLayout = "2006-01-02T15:04:05.000Z"
+
+// signedOps lists the operations that must be signed
+var signedOps = map[string]bool{
+	"MakeLocalPayment":               true,
+	"CreateScheduledPayment":         true,
+	"TransferToSavingsGoal":          true,
+	"TransferFromSavingsGoal":        true,
+	"TransferToAccountSavingsGoal":   true,
+	"TransferFromAccountSavingsGoal": true,
+	"TransferToSpendingSpace":        true,
+	"TransferFromSpendingSpace":      true,
+	"CreateRecurringTransfer":        true,
+	"DeleteRecurringTransfer":        true,
+	"ActivateRoundUp":                true,
+	"DeactivateRoundUp":              true,
+	"CreateContactAccount":           true,
+	"DeleteContact":                  true,
+	"CreatePayee":                    true,
+	"UpdatePayee":                    true,
+	"DeletePayee":                    true,
+	"CreatePayeeAccount":             true,
+	"UpdatePayeeAccount":             true,
+	"DeletePayeeAccount":             true,
+	"DeleteDirectDebitMandate":       true,
+	"LockCard":                       true,
+	"UnlockCard":                     true,
+	"SetCardChannel":                 true,
+}
+
+// RequiresSignature reports whether requests made by the named client method, e.g.
+// "MakeLocalPayment", must be signed.
+func RequiresSignature(op string) bool {
+	return signedOps[op]
+}
+
+// Signer signs requests using a private key registered with Starling. The signature
+// covers the request target, the Date header and a Digest header containing the SHA-512
+// hash of the request body.
+type Signer struct {
+	KeyUID string
+	Key    *rsa.PrivateKey
+
+	now func() time.Time
+}
+
+// NewSigner returns a Signer for the PEM encoded RSA private key registered with Starling
+// under keyUID. Both PKCS #1 and PKCS #8 keys are supported.
+func NewSigner(keyUID string, key []byte) (*Signer, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("unable to decode signing key: no PEM data found")
+	}
+
+	if k, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return &Signer{KeyUID: keyUID, Key: k}, nil
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse signing key")
+	}
+
+	rk, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("unable to use signing key: unsupported key type %T", k)
+	}
+	return &Signer{KeyUID: keyUID, Key: rk}, nil
+}
+
+// Sign sets the Digest and Date headers on the request and appends the signature to the
+// Authorization header. The request body is read and replaced so that it can still be
+// sent. The bearer token must already be set in the Authorization header.
+func (s *Signer) Sign(req *http.Request) error {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return errors.Wrap(err, "unable to read request body")
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	now := time.Now
+	if s.now != nil {
+		now = s.now
+	}
+
+	sum := sha512.Sum512(body)
+	digest := base64.StdEncoding.EncodeToString(sum[:])
+	date := now().UTC().Format(signatureDateLayout)
+
+	target := strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+	signed := "(request-target): " + target + "\nDate: " + date + "\nDigest: " + digest
+	hashed := sha512.Sum512([]byte(signed))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.Key, crypto.SHA512, hashed[:])
+	if err != nil {
+		return errors.Wrap(err, "unable to sign request")
+	}
+
+	auth := fmt.Sprintf(`Signature keyid="%s",algorithm="rsa-sha512",headers="(request-target) Date Digest",signature="%s"`,
+		s.KeyUID, base64.StdEncoding.EncodeToString(sig))
+	if bearer := req.Header.Get("Authorization"); bearer != "" {
+		auth = bearer + ";" + auth
+	}
+
+	req.Header.Set("Digest", digest)
+	req.Header.Set("Date", date)
+	req.Header.Set("Authorization", auth)
+	return nil
+}
+
+// Transport returns a RoundTripper that signs requests to endpoints that require a
+// signature before passing them to base. As the signature is appended to the bearer token
+// it must be used beneath the transport that authenticates requests:
+//
+//	hc := &http.Client{Transport: &oauth2.Transport{Source: ts, Base: signer.Transport(nil)}}
+//
+// If base is nil, http.DefaultTransport is used.
+func (s *Signer) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &signingTransport{signer: s, base: base}
+}
+
+type signingTransport struct {
+	signer *Signer
+	base   http.RoundTripper
+}
+
+// RoundTrip signs a copy of the request if required and sends it.
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, ok := requestOp(req)
+	if !ok || !signedOps[op] {
+		return t.base.RoundTrip(req)
+	}
+
+	signed := req.WithContext(req.Context())
+	signed.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		signed.Header[k] = append([]string(nil), v...)
+	}
+
+	if err := t.signer.Sign(signed); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.base.RoundTrip(signed)
+}
@@ -0,0 +1,120 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+)
+
+// SpendingSpaceType describes how money in a spending space is spent
+type SpendingSpaceType string
+
+// Types of spending space
+const (
+	SpendingSpaceCard         SpendingSpaceType = "CARD"
+	SpendingSpaceBillsManager SpendingSpaceType = "BILLS_MANAGER"
+)
+
+// SpendingSpace is a space within an account that money can be spent from directly,
+// either using a card assigned to the space or by paying bills.
+type SpendingSpace struct {
+	UID                string            `json:"spaceUid"`
+	Name               string            `json:"name"`
+	Balance            Amount            `json:"balance"`
+	CardAssociationUID string            `json:"cardAssociationUid"`
+	SortOrder          int32             `json:"sortOrder"`
+	Type               SpendingSpaceType `json:"spendingSpaceType"`
+}
+
+// Spaces are the savings goals and spending spaces held within an account
+type Spaces struct {
+	SavingsGoals   []AccountSavingsGoal `json:"savingsGoals"`
+	SpendingSpaces []SpendingSpace      `json:"spendingSpaces"`
+}
+
+// Spaces returns the spaces of all kinds held within an account.
+//
+// Note: Spaces uses the v2 API which is still under active development.
+func (c *Client) Spaces(ctx context.Context, act string) (*Spaces, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account/"+act+"/spaces", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var s *Spaces
+	resp, err := c.Do(ctx, req, &s)
+	return s, resp, err
+}
+
+// SpendingSpace returns a spending space, including its balance.
+//
+// Note: SpendingSpace uses the v2 API which is still under active development.
+func (c *Client) SpendingSpace(ctx context.Context, act, uid string) (*SpendingSpace, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/account/"+act+"/spaces/spending/"+uid, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var s *SpendingSpace
+	resp, err := c.Do(ctx, req, &s)
+	return s, resp, err
+}
+
+// TransferToSpendingSpace moves money from the main balance of an account into one of its
+// spending spaces. Each call is made with a new transfer UID; use
+// TransferToSpendingSpaceWithUID if the transfer may need to be retried. The result is
+// returned along with an error if the API reports that the transfer failed.
+//
+// Note: TransferToSpendingSpace uses the v2 API which is still under active development.
+func (c *Client) TransferToSpendingSpace(ctx context.Context, act, uid string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.TransferToSpendingSpaceWithUID(ctx, act, uid, "", a)
+}
+
+// TransferToSpendingSpaceWithUID moves money from the main balance of an account into one
+// of its spending spaces using the transfer UID provided by the caller, see TransferResult.
+// The result is returned along with an error if the API reports that the transfer failed.
+//
+// Note: TransferToSpendingSpaceWithUID uses the v2 API which is still under active
+// development.
+func (c *Client) TransferToSpendingSpaceWithUID(ctx context.Context, act, uid, txnUID string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.transferWithBalance(ctx, "/api/v2/account/"+act+"/spaces/spending/"+uid+"/add-money", txnUID, a, c.spendingSpaceBalance(act, uid))
+}
+
+// TransferFromSpendingSpace moves money out of a spending space into the main balance of
+// the account that holds it. Each call is made with a new transfer UID; use
+// TransferFromSpendingSpaceWithUID if the transfer may need to be retried. The result is
+// returned along with an error if the API reports that the transfer failed.
+//
+// Note: TransferFromSpendingSpace uses the v2 API which is still under active development.
+func (c *Client) TransferFromSpendingSpace(ctx context.Context, act, uid string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.TransferFromSpendingSpaceWithUID(ctx, act, uid, "", a)
+}
+
+// TransferFromSpendingSpaceWithUID moves money out of a spending space into the main
+// balance of the account that holds it using the transfer UID provided by the caller, see
+// TransferResult. The result is returned along with an error if the API reports that the
+// transfer failed.
+//
+// Note: TransferFromSpendingSpaceWithUID uses the v2 API which is still under active
+// development.
+func (c *Client) TransferFromSpendingSpaceWithUID(ctx context.Context, act, uid, txnUID string, a Amount) (*TransferResult, *http.Response, error) {
+	return c.transferWithBalance(ctx, "/api/v2/account/"+act+"/spaces/spending/"+uid+"/withdraw-money", txnUID, a, c.spendingSpaceBalance(act, uid))
+}
+
+// spendingSpaceBalance returns a function that retrieves the balance of a spending space.
+func (c *Client) spendingSpaceBalance(act, uid string) func(context.Context) (*Amount, error) {
+	return func(ctx context.Context) (*Amount, error) {
+		s, _, err := c.SpendingSpace(ctx, act, uid)
+		if err != nil || s == nil {
+			return nil, err
+		}
+		return &s.Balance, nil
+	}
+}
+
+// SpaceFeed returns the feed items for a space. Each space has its own category, which
+// shares the UID of the space, so this is equivalent to calling Feed with the space UID.
+//
+// Note: SpaceFeed uses the v2 API which is still under active development.
+func (c *Client) SpaceFeed(ctx context.Context, act, uid string, opts *FeedOpts) ([]Item, *http.Response, error) {
+	return c.Feed(ctx, act, uid, opts)
+}
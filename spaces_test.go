@@ -0,0 +1,237 @@
+package starling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+const spacesMock = `{
+	"savingsGoals": [
+		{
+			"savingsGoalUid": "e43d3060-2c83-4bb9-ac8c-c627b9c45f8b",
+			"name": "Trip to Paris",
+			"target": {"currency": "GBP", "minorUnits": 11223344},
+			"totalSaved": {"currency": "GBP", "minorUnits": 5611672},
+			"savedPercentage": 50,
+			"state": "ACTIVE"
+		}
+	],
+	"spendingSpaces": [
+		{
+			"spaceUid": "a7d3a4d8-3f5a-4d2e-9b3b-2c1f5a6e7d8c",
+			"name": "Groceries",
+			"balance": {"currency": "GBP", "minorUnits": 12050},
+			"cardAssociationUid": "4b3c2d1e-5f6a-4b7c-8d9e-0f1a2b3c4d5e",
+			"sortOrder": 1,
+			"spendingSpaceType": "CARD"
+		},
+		{
+			"spaceUid": "c1e2d3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f",
+			"name": "Bills",
+			"balance": {"currency": "GBP", "minorUnits": 45000},
+			"sortOrder": 2,
+			"spendingSpaceType": "BILLS_MANAGER"
+		}
+	]
+}`
+
+// TestSpaces confirms that the client is able to list the spaces of all kinds held within
+// an account.
+func TestSpaces(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+
+	mux.HandleFunc("/api/v2/account/"+act+"/spaces", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprint(w, spacesMock)
+	})
+
+	got, _, err := client.Spaces(context.Background(), act)
+	checkNoError(t, err)
+
+	want := &Spaces{}
+	json.Unmarshal([]byte(spacesMock), want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Error("should return spaces matching the mock response", cross)
+	}
+
+	if len(got.SavingsGoals) != 1 || len(got.SpendingSpaces) != 2 {
+		t.Fatal("should return savings goals and spending spaces", cross, got)
+	}
+
+	if got.SpendingSpaces[1].Type != SpendingSpaceBillsManager {
+		t.Error("should return the type of each spending space", cross, got.SpendingSpaces[1].Type)
+	}
+}
+
+// TestSpendingSpace confirms that the client is able to retrieve the details and balance of
+// a spending space.
+func TestSpendingSpace(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	mock := `{
+		"spaceUid": "a7d3a4d8-3f5a-4d2e-9b3b-2c1f5a6e7d8c",
+		"name": "Groceries",
+		"balance": {"currency": "GBP", "minorUnits": 12050},
+		"cardAssociationUid": "4b3c2d1e-5f6a-4b7c-8d9e-0f1a2b3c4d5e",
+		"sortOrder": 1,
+		"spendingSpaceType": "CARD"
+	}`
+
+	mux.HandleFunc("/api/v2/account/"+act+"/spaces/spending/a7d3a4d8-3f5a-4d2e-9b3b-2c1f5a6e7d8c", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprint(w, mock)
+	})
+
+	got, _, err := client.SpendingSpace(context.Background(), act, "a7d3a4d8-3f5a-4d2e-9b3b-2c1f5a6e7d8c")
+	checkNoError(t, err)
+
+	want := &SpendingSpace{}
+	json.Unmarshal([]byte(mock), want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Error("should return a spending space matching the mock response", cross, got)
+	}
+
+	if got.Balance.MinorUnits != 12050 {
+		t.Error("should return the balance of the spending space", cross, got.Balance)
+	}
+}
+
+var spendingSpaceTransferTC = []struct {
+	name      string
+	direction string
+	transfer  func(*Client, context.Context, string, string, Amount) (*TransferResult, *http.Response, error)
+}{
+	{
+		name:      "transfer to spending space",
+		direction: "add-money",
+		transfer:  (*Client).TransferToSpendingSpace,
+	},
+	{
+		name:      "transfer from spending space",
+		direction: "withdraw-money",
+		transfer:  (*Client).TransferFromSpendingSpace,
+	},
+}
+
+// TestSpendingSpaceTransfer confirms that the client is able to move money between the main
+// balance and a spending space.
+func TestSpendingSpaceTransfer(t *testing.T) {
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	space := "a7d3a4d8-3f5a-4d2e-9b3b-2c1f5a6e7d8c"
+	mockAmount := Amount{Currency: "GBP", MinorUnits: 2500}
+	mockResp := `{"transferUid": "28dff346-dd48-426f-96df-d7f33d29c379", "success": true, "errors": []}`
+
+	for _, tc := range spendingSpaceTransferTC {
+		t.Run(tc.name, func(st *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			mux.HandleFunc("/api/v2/account/"+act+"/spaces/spending/"+space+"/", func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(st, r, "PUT")
+
+				var tu = topUpRequest{}
+				if err := json.NewDecoder(r.Body).Decode(&tu); err != nil {
+					st.Fatal("should send a request that the API can parse", cross, err)
+				}
+
+				if tu.Amount != mockAmount {
+					st.Error("should send an amount that matches the mock", cross, tu.Amount)
+				}
+
+				if resource := path.Base(path.Dir(r.URL.Path)); resource != tc.direction {
+					st.Error("should make a request to "+tc.direction, cross, resource)
+				}
+
+				if _, err := uuid.Parse(path.Base(r.URL.Path)); err != nil {
+					st.Error("should send the transfer with a valid UID", cross, err)
+				}
+
+				fmt.Fprint(w, mockResp)
+			})
+
+			mux.HandleFunc("/api/v2/account/"+act+"/spaces/spending/"+space, func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(st, r, "GET")
+				fmt.Fprintf(w, `{"spaceUid": "%s", "balance": {"currency": "GBP", "minorUnits": 7500}}`, space)
+			})
+
+			got, _, err := tc.transfer(client, context.Background(), act, space, mockAmount)
+			checkNoError(st, err)
+
+			if got == nil || got.UID != "28dff346-dd48-426f-96df-d7f33d29c379" {
+				st.Fatal("should return the UID assigned to the transfer", cross, got)
+			}
+
+			if got.Balance == nil || *got.Balance != (Amount{Currency: "GBP", MinorUnits: 7500}) {
+				st.Error("should return the resulting balance of the space", cross, got.Balance)
+			}
+		})
+	}
+}
+
+// TestTransferFromSpendingSpaceWithUID confirms that the client sends the transfer UID
+// supplied by the caller.
+func TestTransferFromSpendingSpaceWithUID(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	space := "a7d3a4d8-3f5a-4d2e-9b3b-2c1f5a6e7d8c"
+	txnUID := "28dff346-dd48-426f-96df-d7f33d29c379"
+
+	mux.HandleFunc("/api/v2/account/"+act+"/spaces/spending/"+space+"/withdraw-money/", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "PUT")
+
+		if reqUID := path.Base(r.URL.Path); reqUID != txnUID {
+			t.Error("should send the transfer UID supplied by the caller", cross, reqUID)
+		}
+
+		fmt.Fprintf(w, `{"transferUid": "%s", "success": true, "errors": []}`, txnUID)
+	})
+
+	got, _, err := client.TransferFromSpendingSpaceWithUID(context.Background(), act, space, txnUID, Amount{Currency: "GBP", MinorUnits: 2500})
+	checkNoError(t, err)
+
+	if got.UID != txnUID {
+		t.Error("should return the UID of the transfer", cross, got.UID)
+	}
+
+	if got.Balance != nil {
+		t.Error("should leave the balance unset if the space cannot be retrieved", cross, got.Balance)
+	}
+}
+
+// TestSpaceFeed confirms that the client retrieves the feed items for a space from the
+// category sharing its UID.
+func TestSpaceFeed(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	act := "b7ab9d05-3d52-4fe2-bd79-cae5b2ac3d1e"
+	space := "a7d3a4d8-3f5a-4d2e-9b3b-2c1f5a6e7d8c"
+
+	mux.HandleFunc("/api/v2/feed/account/"+act+"/category/"+space, func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, "GET")
+		fmt.Fprint(w, `{"feedItems": [{"feedItemUid": "f1", "categoryUid": "`+space+`", "amount": {"currency": "GBP", "minorUnits": 350}, "direction": "OUT"}]}`)
+	})
+
+	got, _, err := client.SpaceFeed(context.Background(), act, space, nil)
+	checkNoError(t, err)
+
+	if len(got) != 1 || got[0].CategoryUID != space {
+		t.Error("should return the feed items for the space", cross, got)
+	}
+}
@@ -0,0 +1,80 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SpendingInsights is a summary of the spending on an account for a given month,
+// broken down by spending category, counter-party or country.
+type SpendingInsights struct {
+	Period              string              `json:"period"`
+	TotalSpent          float64             `json:"totalSpent"`
+	TotalReceived       float64             `json:"totalReceived"`
+	NetSpend            float64             `json:"netSpend"`
+	TotalSpendNetOut    float64             `json:"totalSpendNetOut"`
+	TotalReceivedNetOut float64             `json:"totalReceivedNetOut"`
+	Currency            string              `json:"currency"`
+	Direction           string              `json:"direction"`
+	Breakdown           []SpendingBreakdown `json:"breakdown"`
+}
+
+// SpendingBreakdown is a single line in a SpendingInsights summary. Only the
+// fields relating to the requested breakdown are populated.
+type SpendingBreakdown struct {
+	SpendingCategory string  `json:"spendingCategory,omitempty"`
+	CounterPartyUID  string  `json:"counterPartyUid,omitempty"`
+	CounterPartyType string  `json:"counterPartyType,omitempty"`
+	CounterPartyName string  `json:"counterPartyName,omitempty"`
+	CountryCode      string  `json:"countryCode,omitempty"`
+	TotalSpent       float64 `json:"totalSpent"`
+	TotalReceived    float64 `json:"totalReceived"`
+	NetSpend         float64 `json:"netSpend"`
+	NetDirection     string  `json:"netDirection"`
+	Currency         string  `json:"currency"`
+	Percentage       float64 `json:"percentage"`
+	TransactionCount int64   `json:"transactionCount"`
+}
+
+// SpendingByCategory returns the spending on an account for a given month broken down by
+// spending category.
+// Note: SpendingByCategory uses the v2 API which is still under active development.
+func (c *Client) SpendingByCategory(ctx context.Context, act string, year int, month time.Month) (*SpendingInsights, *http.Response, error) {
+	return c.spendingInsights(ctx, act, "spending-category", year, month)
+}
+
+// SpendingByCounterParty returns the spending on an account for a given month broken down by
+// counter-party.
+// Note: SpendingByCounterParty uses the v2 API which is still under active development.
+func (c *Client) SpendingByCounterParty(ctx context.Context, act string, year int, month time.Month) (*SpendingInsights, *http.Response, error) {
+	return c.spendingInsights(ctx, act, "counter-party", year, month)
+}
+
+// SpendingByCountry returns the spending on an account for a given month broken down by
+// country.
+// Note: SpendingByCountry uses the v2 API which is still under active development.
+func (c *Client) SpendingByCountry(ctx context.Context, act string, year int, month time.Month) (*SpendingInsights, *http.Response, error) {
+	return c.spendingInsights(ctx, act, "country", year, month)
+}
+
+func (c *Client) spendingInsights(ctx context.Context, act, breakdown string, year int, month time.Month) (*SpendingInsights, *http.Response, error) {
+	req, err := c.NewRequest("GET", "/api/v2/accounts/"+act+"/spending-insights/"+breakdown, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	q := req.URL.Query()
+	q.Add("year", strconv.Itoa(year))
+	q.Add("month", strings.ToUpper(month.String()))
+	req.URL.RawQuery = q.Encode()
+
+	var si *SpendingInsights
+	resp, err := c.Do(ctx, req, &si)
+	if err != nil {
+		return nil, resp, err
+	}
+	return si, resp, nil
+}
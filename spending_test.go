@@ -0,0 +1,125 @@
+package starling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var spendingTC = []struct {
+	name      string
+	breakdown string
+	call      func(*Client, context.Context, string, int, time.Month) (*SpendingInsights, *http.Response, error)
+	mock      string
+}{
+	{
+		name:      "by spending category",
+		breakdown: "spending-category",
+		call:      (*Client).SpendingByCategory,
+		mock: `{
+			"period": "2019-01",
+			"totalSpent": 43.5,
+			"totalReceived": 10,
+			"netSpend": 33.5,
+			"totalSpendNetOut": 33.5,
+			"totalReceivedNetOut": 0,
+			"currency": "GBP",
+			"direction": "OUT",
+			"breakdown": [
+				{
+					"spendingCategory": "EATING_OUT",
+					"totalSpent": 43.5,
+					"totalReceived": 10,
+					"netSpend": 33.5,
+					"netDirection": "OUT",
+					"currency": "GBP",
+					"percentage": 100,
+					"transactionCount": 3
+				}
+			]
+		}`,
+	},
+	{
+		name:      "by counter-party",
+		breakdown: "counter-party",
+		call:      (*Client).SpendingByCounterParty,
+		mock: `{
+			"period": "2019-01",
+			"totalSpent": 12.99,
+			"totalReceived": 0,
+			"netSpend": 12.99,
+			"currency": "GBP",
+			"direction": "OUT",
+			"breakdown": [
+				{
+					"counterPartyUid": "e6dbe57e-7c23-4015-97a4-4afbbf7faa23",
+					"counterPartyType": "MERCHANT",
+					"counterPartyName": "Borough Barista",
+					"totalSpent": 12.99,
+					"totalReceived": 0,
+					"netSpend": 12.99,
+					"netDirection": "OUT",
+					"currency": "GBP",
+					"percentage": 100,
+					"transactionCount": 1
+				}
+			]
+		}`,
+	},
+}
+
+func TestSpendingInsights(t *testing.T) {
+	for _, tc := range spendingTC {
+		t.Run(tc.name, func(st *testing.T) {
+			client, mux, _, teardown := setup()
+			defer teardown()
+
+			act := "24492cc9-77dd-4155-87a2-ec2580daf139"
+			mux.HandleFunc("/api/v2/accounts/"+act+"/spending-insights/"+tc.breakdown, func(w http.ResponseWriter, r *http.Request) {
+				checkMethod(st, r, http.MethodGet)
+
+				if got, want := r.URL.Query().Get("year"), "2019"; got != want {
+					st.Error("should send the year as a query parameter", cross, got)
+				}
+
+				if got, want := r.URL.Query().Get("month"), "JANUARY"; got != want {
+					st.Error("should send the month as a query parameter", cross, got)
+				}
+
+				fmt.Fprint(w, tc.mock)
+			})
+
+			got, _, err := tc.call(client, context.Background(), act, 2019, time.January)
+			checkNoError(st, err)
+
+			want := new(SpendingInsights)
+			json.Unmarshal([]byte(tc.mock), want)
+
+			if !reflect.DeepEqual(got, want) {
+				st.Error("should return spending insights matching the mock response", cross)
+			}
+		})
+	}
+}
+
+func TestSpendingInsightsForbidden(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/api/v2/accounts/", func(w http.ResponseWriter, r *http.Request) {
+		checkMethod(t, r, http.MethodGet)
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	got, resp, err := client.SpendingByCountry(context.Background(), "24492cc9-77dd-4155-87a2-ec2580daf139", 2019, time.January)
+	checkHasError(t, err)
+	checkStatus(t, resp, http.StatusForbidden)
+
+	if got != nil {
+		t.Error("should not return spending insights", cross)
+	}
+}
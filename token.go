@@ -0,0 +1,156 @@
+package starling
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// defaultExpiryWindow is how long before the token expires that the client starts treating
+// it as about to expire
+const defaultExpiryWindow = 5 * time.Minute
+
+// TokenRefresher obtains a new access token for the HTTP client used by a Client.
+// RefreshToken is called when the token is about to expire and when a request is rejected
+// as unauthorised.
+type TokenRefresher interface {
+	RefreshToken(ctx context.Context) error
+}
+
+// Expiry returns the time at which the token expires. ExpiresAt is used if it can be
+// parsed, otherwise ExpiresInSeconds is counted from now.
+func (i Identity) Expiry(now time.Time) time.Time {
+	if t, err := time.Parse(time.RFC3339, i.ExpiresAt); err == nil {
+		return t
+	}
+	return now.Add(time.Duration(i.ExpiresInSeconds) * time.Second)
+}
+
+// TokenExpiry returns the time at which the token used by the client expires. The expiry
+// is retrieved using CurrentUser and cached until the token is refreshed.
+func (c *Client) TokenExpiry(ctx context.Context) (time.Time, error) {
+	c.tokenMu.Lock()
+	exp := c.expiresAt
+	c.tokenMu.Unlock()
+
+	if !exp.IsZero() {
+		return exp, nil
+	}
+
+	ident, _, err := c.CurrentUser(ctx)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "unable to retrieve token expiry")
+	}
+	if ident == nil {
+		return time.Time{}, errors.New("unable to retrieve token expiry: no identity returned")
+	}
+	exp = ident.Expiry(time.Now())
+
+	c.tokenMu.Lock()
+	c.expiresAt = exp
+	c.tokenMu.Unlock()
+
+	return exp, nil
+}
+
+// tracksExpiry reports whether the client has been configured to monitor token expiry.
+func (c *Client) tracksExpiry() bool {
+	return c.refresher != nil || c.onExpiry != nil
+}
+
+// generation returns the number of times the token has been refreshed.
+func (c *Client) generation() int {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	return c.refreshes
+}
+
+// checkExpiry notifies the caller and refreshes the token if it is about to expire. It
+// returns the token generation that the request will be sent with.
+func (c *Client) checkExpiry(ctx context.Context, req *http.Request) (int, error) {
+	gen := c.generation()
+	if r, ok := lookup(req.Method, req.URL.Path); ok && r.op == "CurrentUser" {
+		return gen, nil
+	}
+
+	exp, err := c.TokenExpiry(ctx)
+	if err != nil {
+		return gen, err
+	}
+
+	window := c.expiryWindow
+	if window == 0 {
+		window = defaultExpiryWindow
+	}
+
+	if time.Until(exp) > window {
+		return gen, nil
+	}
+
+	c.tokenMu.Lock()
+	notify := !c.notified && c.onExpiry != nil
+	c.notified = true
+	c.tokenMu.Unlock()
+
+	if notify {
+		c.onExpiry(exp)
+	}
+
+	if c.refresher == nil {
+		return gen, nil
+	}
+
+	if err := c.refresh(ctx, gen); err != nil {
+		return gen, err
+	}
+	return c.generation(), nil
+}
+
+// refresh refreshes the token unless it has already been refreshed since generation gen.
+// Cached token details are discarded so that they are retrieved again for the new token.
+func (c *Client) refresh(ctx context.Context, gen int) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if c.generation() != gen {
+		return nil
+	}
+
+	if err := c.refresher.RefreshToken(ctx); err != nil {
+		return errors.Wrap(err, "unable to refresh token")
+	}
+
+	c.tokenMu.Lock()
+	c.refreshes++
+	c.expiresAt = time.Time{}
+	c.notified = false
+	c.tokenMu.Unlock()
+
+	c.scopesMu.Lock()
+	c.scopes = nil
+	c.scopesMu.Unlock()
+
+	return nil
+}
+
+// retry returns a copy of req that can be sent again after the token has been refreshed.
+// False is returned if the request body cannot be rewound.
+func retry(req *http.Request) (*http.Request, bool) {
+	r := req.WithContext(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return r, true
+	}
+
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	r.Body = body
+	return r, true
+}
@@ -0,0 +1,168 @@
+package starling
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type refresherFunc func(ctx context.Context) error
+
+func (f refresherFunc) RefreshToken(ctx context.Context) error { return f(ctx) }
+
+func TestIdentityExpiry(t *testing.T) {
+	now := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ident := Identity{ExpiresAt: "2019-01-01T13:00:00.000Z", ExpiresInSeconds: 60}
+	if got := ident.Expiry(now); !got.Equal(time.Date(2019, 1, 1, 13, 0, 0, 0, time.UTC)) {
+		t.Error("should use the expiry time when it can be parsed", cross, got)
+	}
+
+	ident = Identity{ExpiresInSeconds: 60}
+	if got := ident.Expiry(now); !got.Equal(now.Add(time.Minute)) {
+		t.Error("should count the seconds to expiry from now", cross, got)
+	}
+}
+
+func TestTokenExpiryRefresh(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	refreshes, notifications := 0, 0
+	client.refresher = refresherFunc(func(ctx context.Context) error {
+		refreshes++
+		return nil
+	})
+	client.onExpiry = func(time.Time) { notifications++ }
+
+	mux.HandleFunc("/api/v1/me", func(w http.ResponseWriter, r *http.Request) {
+		expiresIn := 60
+		if refreshes > 0 {
+			expiresIn = 3600
+		}
+		fmt.Fprintf(w, `{"customerUid": "c1", "authenticated": true, "expiresInSeconds": %d}`, expiresIn)
+	})
+
+	mux.HandleFunc("/api/v1/accounts/balance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"currency": "GBP"}`)
+	})
+
+	for i := 0; i < 3; i++ {
+		_, _, err := client.AccountBalance(context.Background())
+		checkNoError(t, err)
+	}
+
+	if refreshes != 1 {
+		t.Error("should refresh a token that is about to expire once", cross, refreshes)
+	}
+
+	if notifications != 1 {
+		t.Error("should notify the caller once before the token expires", cross, notifications)
+	}
+
+	exp, err := client.TokenExpiry(context.Background())
+	checkNoError(t, err)
+
+	if time.Until(exp) < 50*time.Minute {
+		t.Error("should track the expiry of the refreshed token", cross, exp)
+	}
+}
+
+func TestRetryOnUnauthorized(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	refreshes := 0
+	client.refresher = refresherFunc(func(ctx context.Context) error {
+		refreshes++
+		return nil
+	})
+
+	mux.HandleFunc("/api/v1/me", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"customerUid": "c1", "authenticated": true, "expiresInSeconds": 3600}`)
+	})
+
+	calls := 0
+	mux.HandleFunc("/api/v1/payments/local", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := ioutil.ReadAll(r.Body)
+		if len(body) == 0 {
+			t.Error("should send the request body with each attempt", cross, calls)
+		}
+
+		if calls == 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	})
+
+	resp, err := client.MakeLocalPayment(context.Background(), LocalPayment{Reference: "retry"})
+	checkNoError(t, err)
+	checkStatus(t, resp, http.StatusOK)
+
+	if calls != 2 || refreshes != 1 {
+		t.Error("should refresh the token and retry the request once", cross, calls, refreshes)
+	}
+}
+
+func TestRetryOnUnauthorizedOnce(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	refreshes := 0
+	client.refresher = refresherFunc(func(ctx context.Context) error {
+		refreshes++
+		return nil
+	})
+
+	mux.HandleFunc("/api/v1/me", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"customerUid": "c1", "authenticated": true, "expiresInSeconds": 3600}`)
+	})
+
+	calls := 0
+	mux.HandleFunc("/api/v1/accounts/balance", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	_, resp, err := client.AccountBalance(context.Background())
+	checkHasError(t, err)
+	checkStatus(t, resp, http.StatusUnauthorized)
+
+	if _, ok := err.(AuthError); !ok {
+		t.Error("should return an AuthError", cross, err)
+	}
+
+	if calls != 2 || refreshes != 1 {
+		t.Error("should only retry the request once", cross, calls, refreshes)
+	}
+}
+
+func TestRetryOnUnauthorizedCheckingScopes(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.checkScopes = true
+
+	client.refresher = refresherFunc(func(ctx context.Context) error {
+		return nil
+	})
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := client.Accounts(context.Background())
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		checkHasError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("should not deadlock when the token is refreshed while retrieving scopes", cross)
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Package validation checks UK sort codes and account numbers, IBANs and BICs before they are
+sent to the Starling API.
+
+Sort codes and account numbers are checked using the Vocalink modulus checking rules. The
+rules are loaded from the weight table and sort code substitution table published by
+Vocalink, which are updated several times a year:
+
+	m, err := validation.LoadModulusFiles("valacdos.txt", "scsubtab.txt")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := m.Check("08-99-99", "66374958"); err != nil {
+		fmt.Println(err)
+	}
+
+A Modulus can be set as the AccountValidator of a Starling client so that contact and
+payee accounts are checked before they are created:
+
+	client := starling.NewClientWithOptions(tc, starling.ClientOptions{AccountValidator: m})
+
+An account that passes the modulus check may still not exist. A sort code that is not in
+the weight table cannot be checked and is treated as valid.
+
+IBANs are checked against the length registered for their country and their check digits,
+and can be formatted for print or electronic use. UK sort codes and account numbers can be
+converted to and from GB IBANs:
+
+	iban, err := validation.GBIBAN("SRLG", "60-83-71", "12345678")
+	fmt.Println(validation.PrintIBAN(iban))
+
+	sortCode, accountNumber, err := validation.GBAccount(iban)
+*/
+package validation
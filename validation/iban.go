@@ -0,0 +1,175 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ibanLengths is the length of the IBAN for each country in the SWIFT IBAN registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22,
+	"BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24,
+	"DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24, "FI": 18,
+	"FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18, "GR": 27,
+	"GT": 28, "HN": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26,
+	"IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20,
+	"LU": 20, "LV": 21, "LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20,
+	"MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33, "SA": 24,
+	"SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25,
+	"SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+	"YE": 30,
+}
+
+// Errors returned when checking an IBAN or BIC
+var (
+	ErrInvalidIBAN        = errors.New("invalid IBAN: must be a country code and check digits followed by letters and digits")
+	ErrUnknownIBANCountry = errors.New("invalid IBAN: country does not use IBANs")
+	ErrIBANLength         = errors.New("invalid IBAN: wrong length for the country")
+	ErrIBANChecksum       = errors.New("invalid IBAN: check digits do not match")
+	ErrNotGBIBAN          = errors.New("invalid IBAN: not a GB IBAN")
+	ErrInvalidBIC         = errors.New("invalid BIC: must be a bank code, country code and location, optionally followed by a branch code")
+	ErrInvalidBankCode    = errors.New("invalid bank code: must be four letters")
+)
+
+// UsesIBAN reports whether accounts in the country are identified by an IBAN.
+func UsesIBAN(country string) bool {
+	_, ok := ibanLengths[strings.ToUpper(country)]
+	return ok
+}
+
+// ElectronicIBAN returns the IBAN in electronic format, in upper case without spaces.
+func ElectronicIBAN(iban string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(iban), ""))
+}
+
+// PrintIBAN returns the IBAN in print format, in upper case with a space after every
+// fourth character.
+func PrintIBAN(iban string) string {
+	e := ElectronicIBAN(iban)
+
+	var sb strings.Builder
+	for i, r := range e {
+		if i > 0 && i%4 == 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// CheckIBAN reports whether the IBAN has the length registered for its country and valid
+// check digits. The IBAN may be in print or electronic format.
+func CheckIBAN(iban string) error {
+	e := ElectronicIBAN(iban)
+	if len(e) < 5 || !isLetters(e[:2]) || !isDigits(e[2:4], 2) || !isAlphanumeric(e[4:]) {
+		return ErrInvalidIBAN
+	}
+
+	n, ok := ibanLengths[e[:2]]
+	if !ok {
+		return ErrUnknownIBANCountry
+	}
+
+	if len(e) != n {
+		return ErrIBANLength
+	}
+
+	if mod97(e[4:]+e[:4]) != 1 {
+		return ErrIBANChecksum
+	}
+	return nil
+}
+
+// CheckBIC reports whether the BIC has the structure of a business identifier code: a
+// four letter bank code, a two letter country code, a two character location code and an
+// optional three character branch code.
+func CheckBIC(bic string) error {
+	b := strings.ToUpper(strings.TrimSpace(bic))
+	if len(b) != 8 && len(b) != 11 {
+		return ErrInvalidBIC
+	}
+
+	if !isLetters(b[:6]) || !isAlphanumeric(b[6:]) {
+		return ErrInvalidBIC
+	}
+	return nil
+}
+
+// GBIBAN returns the IBAN, in electronic format, of the UK account with the given sort
+// code and account number at the bank identified by the four letter bank code. The bank
+// code is the first four characters of the BIC of the bank, e.g. SRLG for Starling Bank.
+// Account numbers with fewer than eight digits are padded with leading zeros.
+func GBIBAN(bankCode, sortCode, accountNumber string) (string, error) {
+	bc := strings.ToUpper(bankCode)
+	if !isLetters(bc) || len(bc) != 4 {
+		return "", ErrInvalidBankCode
+	}
+
+	strip := strings.NewReplacer("-", "", " ", "")
+	sc, acc := strip.Replace(sortCode), strip.Replace(accountNumber)
+	if !isDigits(sc, 6) {
+		return "", ErrInvalidSortCode
+	}
+
+	if len(acc) < 6 || len(acc) > 8 || !isDigits(acc, len(acc)) {
+		return "", ErrInvalidAccountNumber
+	}
+
+	bban := bc + sc + strings.Repeat("0", 8-len(acc)) + acc
+	return fmt.Sprintf("GB%02d%s", 98-mod97(bban+"GB00"), bban), nil
+}
+
+// GBAccount returns the sort code and account number of the UK account identified by a GB
+// IBAN. An error is returned if the IBAN is not valid.
+func GBAccount(iban string) (sortCode, accountNumber string, err error) {
+	if err := CheckIBAN(iban); err != nil {
+		return "", "", err
+	}
+
+	e := ElectronicIBAN(iban)
+	if e[:2] != "GB" {
+		return "", "", ErrNotGBIBAN
+	}
+
+	if !isDigits(e[8:], 14) {
+		return "", "", ErrInvalidIBAN
+	}
+	return e[8:14], e[14:], nil
+}
+
+// mod97 returns the remainder when s, with each letter replaced by two digits (A = 10 to
+// Z = 35), is read as a decimal number and divided by 97.
+func mod97(s string) int {
+	r := 0
+	for _, ch := range s {
+		if ch >= 'A' && ch <= 'Z' {
+			r = (r*100 + int(ch-'A'+10)) % 97
+			continue
+		}
+		r = (r*10 + int(ch-'0')) % 97
+	}
+	return r
+}
+
+// isLetters reports whether s consists of upper case letters.
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+// isAlphanumeric reports whether s consists of upper case letters and digits.
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return len(s) > 0
+}
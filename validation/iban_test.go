@@ -0,0 +1,117 @@
+package validation
+
+import "testing"
+
+var checkIBANTC = []struct {
+	name string
+	iban string
+	want error
+}{
+	{"GB electronic format", "GB29NWBK60161331926819", nil},
+	{"GB print format", "GB29 NWBK 6016 1331 9268 19", nil},
+	{"lower case", "gb29nwbk60161331926819", nil},
+	{"DE", "DE89370400440532013000", nil},
+	{"BE", "BE68539007547034", nil},
+	{"FR with letters in the account number", "FR1420041010050500013M02606", nil},
+	{"NL", "NL91ABNA0417164300", nil},
+	{"NI", "NI45BAPR00000013000003558124", nil},
+	{"MN", "MN121234123456789123", nil},
+	{"incorrect check digits", "GB28NWBK60161331926819", ErrIBANChecksum},
+	{"transposed digits", "GB29NWBK60161331926891", ErrIBANChecksum},
+	{"too short for the country", "GB29NWBK6016133192681", ErrIBANLength},
+	{"too long for the country", "DE893704004405320130001", ErrIBANLength},
+	{"country without IBANs", "US29NWBK60161331926819", ErrUnknownIBANCountry},
+	{"check digits are letters", "GBXXNWBK60161331926819", ErrInvalidIBAN},
+	{"punctuation", "GB29-NWBK-6016-1331-9268-19", ErrInvalidIBAN},
+	{"empty", "", ErrInvalidIBAN},
+}
+
+func TestCheckIBAN(t *testing.T) {
+	for _, tc := range checkIBANTC {
+		t.Run(tc.name, func(st *testing.T) {
+			if got := CheckIBAN(tc.iban); got != tc.want {
+				st.Error("should check the IBAN", cross, got)
+			}
+		})
+	}
+}
+
+func TestFormatIBAN(t *testing.T) {
+	if got := PrintIBAN("gb29nwbk60161331926819"); got != "GB29 NWBK 6016 1331 9268 19" {
+		t.Error("should group the IBAN in fours", cross, got)
+	}
+
+	if got := ElectronicIBAN(" GB29 NWBK 6016 1331 9268 19 "); got != "GB29NWBK60161331926819" {
+		t.Error("should remove spaces from the IBAN", cross, got)
+	}
+}
+
+var checkBICTC = []struct {
+	name string
+	bic  string
+	want error
+}{
+	{"eight characters", "SRLGGB2L", nil},
+	{"eleven characters", "COBADEFFXXX", nil},
+	{"lower case", "srlggb2l", nil},
+	{"too short", "SRLGGB2", ErrInvalidBIC},
+	{"nine characters", "SRLGGB2LX", ErrInvalidBIC},
+	{"digit in bank code", "SRL1GB2L", ErrInvalidBIC},
+	{"digit in country code", "SRLGG12L", ErrInvalidBIC},
+	{"punctuation in branch code", "COBADEFFX-X", ErrInvalidBIC},
+}
+
+func TestCheckBIC(t *testing.T) {
+	for _, tc := range checkBICTC {
+		t.Run(tc.name, func(st *testing.T) {
+			if got := CheckBIC(tc.bic); got != tc.want {
+				st.Error("should check the BIC", cross, got)
+			}
+		})
+	}
+}
+
+func TestGBIBAN(t *testing.T) {
+	got, err := GBIBAN("nwbk", "60-16-13", "31926819")
+	if err != nil || got != "GB29NWBK60161331926819" {
+		t.Error("should derive the IBAN from the sort code and account number", cross, got, err)
+	}
+
+	got, err = GBIBAN("SRLG", "608371", "123456")
+	if err != nil || CheckIBAN(got) != nil || got[14:] != "00123456" {
+		t.Error("should pad short account numbers", cross, got, err)
+	}
+
+	if _, err := GBIBAN("SRL", "608371", "12345678"); err != ErrInvalidBankCode {
+		t.Error("should reject an invalid bank code", cross, err)
+	}
+
+	if _, err := GBIBAN("SRLG", "60837", "12345678"); err != ErrInvalidSortCode {
+		t.Error("should reject an invalid sort code", cross, err)
+	}
+
+	if _, err := GBIBAN("SRLG", "608371", "123456789"); err != ErrInvalidAccountNumber {
+		t.Error("should reject an invalid account number", cross, err)
+	}
+}
+
+func TestGBAccount(t *testing.T) {
+	sc, acc, err := GBAccount("GB29 NWBK 6016 1331 9268 19")
+	if err != nil || sc != "601613" || acc != "31926819" {
+		t.Error("should derive the sort code and account number", cross, sc, acc, err)
+	}
+
+	if _, _, err := GBAccount("DE89370400440532013000"); err != ErrNotGBIBAN {
+		t.Error("should reject an IBAN from another country", cross, err)
+	}
+
+	if _, _, err := GBAccount("GB28NWBK60161331926819"); err != ErrIBANChecksum {
+		t.Error("should reject an invalid IBAN", cross, err)
+	}
+}
+
+func TestUsesIBAN(t *testing.T) {
+	if !UsesIBAN("gb") || UsesIBAN("US") {
+		t.Error("should report the countries that use IBANs", cross)
+	}
+}
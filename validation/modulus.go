@@ -0,0 +1,397 @@
+package validation
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Algorithm is the modulus checking algorithm used for a range of sort codes
+type Algorithm string
+
+// Modulus checking algorithms
+const (
+	Standard10      Algorithm = "MOD10"
+	Standard11      Algorithm = "MOD11"
+	DoubleAlternate Algorithm = "DBLAL"
+)
+
+// Errors returned by Check
+var (
+	ErrInvalidSortCode      = errors.New("invalid sort code: must be six digits")
+	ErrInvalidAccountNumber = errors.New("invalid account number: must be between six and ten digits")
+	ErrModulusCheck         = errors.New("account number does not pass the modulus check for the sort code")
+)
+
+// Weight is a row of the weight table. The fourteen weights apply, in order, to the six
+// digits of the sort code followed by the eight digits of the account number, referred to
+// by Vocalink as u v w x y z a b c d e f g h.
+type Weight struct {
+	Start     string
+	End       string
+	Algorithm Algorithm
+	Weights   [14]int
+	Exception int
+}
+
+// Positions of the digits referred to by the exception rules
+const (
+	u = 0
+	a = 6
+	b = 7
+	c = 8
+	g = 12
+	h = 13
+)
+
+// Sort codes and weights substituted by the exception rules
+const (
+	exception8SortCode = "090126"
+	exception9SortCode = "309634"
+)
+
+var (
+	exception2Weights  = [14]int{0, 0, 1, 2, 5, 3, 6, 4, 8, 7, 10, 9, 3, 1}
+	exception2GWeights = [14]int{0, 0, 0, 0, 0, 0, 0, 0, 8, 7, 10, 9, 3, 1}
+)
+
+// Modulus checks sort codes and account numbers using the weight table and sort code
+// substitution table published by Vocalink.
+type Modulus struct {
+	weights []Weight
+	subs    map[string]string
+}
+
+// LoadModulus reads the weight table from r. Each line holds the first and last sort code
+// of a range, the algorithm, fourteen weights and an optional exception number separated
+// by white space. Blank lines are ignored.
+func LoadModulus(r io.Reader) (*Modulus, error) {
+	m := &Modulus{subs: make(map[string]string)}
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		f := strings.Fields(s.Text())
+		if len(f) == 0 {
+			continue
+		}
+
+		w, err := parseWeight(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid weight table on line %d", n)
+		}
+		m.weights = append(m.weights, w)
+	}
+	if err := s.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to read weight table")
+	}
+
+	sort.SliceStable(m.weights, func(i, j int) bool {
+		return m.weights[i].Start < m.weights[j].Start
+	})
+	return m, nil
+}
+
+// parseWeight parses the fields of a row in the weight table.
+func parseWeight(f []string) (Weight, error) {
+	var w Weight
+	if len(f) != 17 && len(f) != 18 {
+		return w, errors.Errorf("expected 17 or 18 fields, got %d", len(f))
+	}
+
+	w.Start, w.End, w.Algorithm = f[0], f[1], Algorithm(f[2])
+	if !isDigits(w.Start, 6) || !isDigits(w.End, 6) {
+		return w, errors.Errorf("invalid sort code range %s to %s", w.Start, w.End)
+	}
+
+	switch w.Algorithm {
+	case Standard10, Standard11, DoubleAlternate:
+	default:
+		return w, errors.Errorf("unknown algorithm %s", w.Algorithm)
+	}
+
+	for i := range w.Weights {
+		n, err := strconv.Atoi(f[3+i])
+		if err != nil {
+			return w, errors.Errorf("invalid weight %s", f[3+i])
+		}
+		w.Weights[i] = n
+	}
+
+	if len(f) == 18 {
+		n, err := strconv.Atoi(f[17])
+		if err != nil || n < 1 || n > 14 {
+			return w, errors.Errorf("invalid exception %s", f[17])
+		}
+		w.Exception = n
+	}
+	return w, nil
+}
+
+// LoadSubstitutions reads the sort code substitution table from r. Each line holds a sort
+// code and the sort code that replaces it when checking accounts covered by exception 5.
+func (m *Modulus) LoadSubstitutions(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		f := strings.Fields(s.Text())
+		if len(f) == 0 {
+			continue
+		}
+
+		if len(f) != 2 || !isDigits(f[0], 6) || !isDigits(f[1], 6) {
+			return errors.Errorf("invalid substitution table on line %d", n)
+		}
+		m.subs[f[0]] = f[1]
+	}
+	return errors.Wrap(s.Err(), "unable to read substitution table")
+}
+
+// LoadModulusFiles reads the weight table and sort code substitution table from the named
+// files. The substitution table is optional and is not read if its name is empty.
+func LoadModulusFiles(weights, substitutions string) (*Modulus, error) {
+	f, err := os.Open(weights)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open weight table")
+	}
+	defer f.Close()
+
+	m, err := LoadModulus(f)
+	if err != nil || substitutions == "" {
+		return m, err
+	}
+
+	sf, err := os.Open(substitutions)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open substitution table")
+	}
+	defer sf.Close()
+
+	if err := m.LoadSubstitutions(sf); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Check reports whether the account number is valid for the sort code. Dashes and spaces
+// are ignored. Account numbers with fewer than eight digits are padded with leading zeros.
+// For nine digit account numbers the first digit replaces the last digit of the sort code.
+// Ten digit account numbers are checked using their first eight digits for sort codes
+// beginning 08 and their last eight digits otherwise.
+//
+// ErrModulusCheck is returned if the account number fails the check. Sort codes that are
+// not in the weight table cannot be checked and are treated as valid.
+func (m *Modulus) Check(sortCode, accountNumber string) error {
+	sc, acc, err := normalise(sortCode, accountNumber)
+	if err != nil {
+		return err
+	}
+
+	ws := m.lookup(sc)
+	if len(ws) == 0 {
+		return nil
+	}
+
+	d := digits(sc, acc)
+	for _, w := range ws {
+		if w.Exception == 6 && d[a] >= 4 && d[a] <= 8 && d[g] == d[h] {
+			// Foreign currency accounts cannot be checked.
+			return nil
+		}
+	}
+
+	if m.valid(ws, sc, acc) {
+		return nil
+	}
+	return ErrModulusCheck
+}
+
+// valid applies the rows of the weight table for a sort code, combining the results of
+// the first and second checks as the exception rules require.
+func (m *Modulus) valid(ws []Weight, sc, acc string) bool {
+	first := m.pass(ws[0], sc, acc)
+	if len(ws) == 1 {
+		return first
+	}
+
+	second, d := ws[1], digits(sc, acc)
+	switch {
+	case ws[0].Exception == 2 && second.Exception == 9:
+		return first || m.pass(second, exception9SortCode, acc)
+	case ws[0].Exception == 10 && second.Exception == 11,
+		ws[0].Exception == 12 && second.Exception == 13:
+		return first || m.pass(second, sc, acc)
+	case second.Exception == 3 && (d[c] == 6 || d[c] == 9):
+		return first
+	}
+	return first && m.pass(second, sc, acc)
+}
+
+// pass applies a single row of the weight table.
+func (m *Modulus) pass(w Weight, sc, acc string) bool {
+	switch w.Exception {
+	case 5:
+		if s, ok := m.subs[sc]; ok {
+			sc = s
+		}
+	case 8:
+		sc = exception8SortCode
+	}
+
+	d := digits(sc, acc)
+	ws := w.Weights
+
+	switch w.Exception {
+	case 2:
+		if d[a] != 0 {
+			ws = exception2Weights
+			if d[g] == 9 {
+				ws = exception2GWeights
+			}
+		}
+	case 7:
+		if d[g] == 9 {
+			zero(&ws)
+		}
+	case 10:
+		if (d[a] == 0 || d[a] == 9) && d[b] == 9 && d[g] == 9 {
+			zero(&ws)
+		}
+	}
+
+	total := sum(w.Algorithm, ws, d)
+
+	switch w.Exception {
+	case 1:
+		total += 27
+	case 4:
+		return total%11 == d[g]*10+d[h]
+	case 5:
+		return exception5(w.Algorithm, total, d)
+	case 14:
+		if total%11 == 0 {
+			return true
+		}
+		if d[h] != 0 && d[h] != 1 && d[h] != 9 {
+			return false
+		}
+		return sum(w.Algorithm, ws, digits(sc, "0"+acc[:7]))%11 == 0
+	}
+
+	if w.Algorithm == Standard11 {
+		return total%11 == 0
+	}
+	return total%10 == 0
+}
+
+// exception5 checks the remainder against the check digit in position g for the standard
+// modulus 11 check and position h for the double alternate check.
+func exception5(alg Algorithm, total int, d [14]int) bool {
+	if alg == Standard11 {
+		r := total % 11
+		switch r {
+		case 0:
+			return d[g] == 0
+		case 1:
+			return false
+		}
+		return 11-r == d[g]
+	}
+
+	r := total % 10
+	if r == 0 {
+		return d[h] == 0
+	}
+	return 10-r == d[h]
+}
+
+// sum returns the weighted total of the digits. For the double alternate algorithm the
+// digits of each product are added rather than the products themselves.
+func sum(alg Algorithm, ws [14]int, d [14]int) int {
+	total := 0
+	for i := range d {
+		p := ws[i] * d[i]
+		if alg == DoubleAlternate {
+			p = p/10 + p%10
+		}
+		total += p
+	}
+	return total
+}
+
+// zero sets the weights for positions u to b to zero.
+func zero(ws *[14]int) {
+	for i := u; i <= b; i++ {
+		ws[i] = 0
+	}
+}
+
+// lookup returns the rows of the weight table covering the sort code, in the order they
+// appear in the table.
+func (m *Modulus) lookup(sc string) []Weight {
+	i := sort.Search(len(m.weights), func(i int) bool {
+		return m.weights[i].Start > sc
+	})
+
+	var ws []Weight
+	for _, w := range m.weights[:i] {
+		if w.End >= sc {
+			ws = append(ws, w)
+		}
+	}
+	return ws
+}
+
+// normalise removes separators from the sort code and account number and converts the
+// account number to eight digits.
+func normalise(sortCode, accountNumber string) (string, string, error) {
+	strip := strings.NewReplacer("-", "", " ", "")
+	sc, acc := strip.Replace(sortCode), strip.Replace(accountNumber)
+
+	if !isDigits(sc, 6) {
+		return "", "", ErrInvalidSortCode
+	}
+
+	if !isDigits(acc, len(acc)) {
+		return "", "", ErrInvalidAccountNumber
+	}
+
+	switch n := len(acc); {
+	case n >= 6 && n <= 8:
+		acc = strings.Repeat("0", 8-n) + acc
+	case n == 9:
+		sc, acc = sc[:5]+acc[:1], acc[1:]
+	case n == 10 && strings.HasPrefix(sc, "08"):
+		acc = acc[:8]
+	case n == 10:
+		acc = acc[2:]
+	default:
+		return "", "", ErrInvalidAccountNumber
+	}
+	return sc, acc, nil
+}
+
+// digits returns the digits of the sort code followed by those of the account number.
+func digits(sc, acc string) [14]int {
+	var d [14]int
+	for i, r := range sc + acc {
+		d[i] = int(r - '0')
+	}
+	return d
+}
+
+// isDigits reports whether s consists of exactly n decimal digits.
+func isDigits(s string, n int) bool {
+	if len(s) != n || n == 0 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,281 @@
+package validation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	tick  = "\u2713"
+	cross = "\u2717"
+)
+
+// weightTable holds made up rows in the format of the Vocalink weight table. They exercise
+// each path through the algorithms and exception rules, but are not taken from the
+// published table, which is checked against the published test cases by TestCheckPublished.
+const weightTable = `
+080008 080008 MOD10    0    0    0    0    0    0    7    1    3    7    1    3    7    1
+900001 900001 DBLAL    0    0    2    1    2    1    2    1    2    1    2    1    2    1    1
+900002 900002 MOD11    0    0    0    0    0    0    0    7    6    5    4    3    2    1    2
+900002 900002 MOD11    0    0    0    0    0    1    8    7    6    5    4    3    2    1    9
+900003 900003 MOD11    0    0    0    0    0    0    0    7    6    5    4    3    2    1
+900003 900003 DBLAL    2    1    2    1    2    1    2    1    2    1    2    1    2    1    3
+900004 900004 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1    4
+900005 900006 MOD11    0    0    4    3    2    7    6    5    4    3    2    7    6    5    5
+900005 900006 DBLAL    2    1    2    1    2    1    2    1    2    1    2    1    2    1    5
+900007 900007 MOD11    0    0    4    3    2    7    6    5    4    3    2    7    6    5    7
+900008 900008 MOD10    0    0    0    0    0    0    7    1    3    7    1    3    7    1
+900009 900009 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1
+900010 900010 MOD11    0    0    6    5    4    3    2    7    6    5    4    3    2    1   10
+900010 900010 MOD11    0    0    7    6    5    4    3    2    7    6    5    4    3    2   11
+900012 900012 MOD11    0    0    6    5    4    3    2    7    6    5    4    3    2    1   12
+900012 900012 MOD11    0    0    7    6    5    4    3    2    7    6    5    4    3    2   13
+900014 900014 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1   14
+900016 900016 MOD11    0    0    0    0    0    0    8    7    6    5    4    3    2    1    6
+900018 900018 MOD11    0    0    0    0    0    1    8    7    6    5    4    3    2    1    8
+`
+
+const substitutionTable = `
+900006 900005
+`
+
+func testModulus(t *testing.T) *Modulus {
+	m, err := LoadModulus(strings.NewReader(weightTable))
+	if err != nil {
+		t.Fatal("should load the weight table", cross, err)
+	}
+
+	if err := m.LoadSubstitutions(strings.NewReader(substitutionTable)); err != nil {
+		t.Fatal("should load the substitution table", cross, err)
+	}
+	return m
+}
+
+var checkTC = []struct {
+	name     string
+	sortCode string
+	account  string
+	valid    bool
+}{
+	// Algorithms
+	{"pass modulus 10 check", "900008", "75749118", true},
+	{"fail modulus 10 check", "900008", "75749119", false},
+	{"pass modulus 11 check", "900009", "07924402", true},
+	{"fail modulus 11 check", "900009", "07924403", false},
+
+	// Exception rules
+	{"exception 1 adds 27 to the total", "900001", "43457011", true},
+	{"exception 1 fails without 27", "900001", "96419907", false},
+	{"exception 2 a is 0 uses the row weights", "900002", "04940326", true},
+	{"exception 2 a is not 0 uses the exception weights", "900002", "99102374", true},
+	{"exception 2 a is not 0 and g is 9", "900002", "27589290", true},
+	{"exception 2 and 9 both checks fail", "900002", "12187664", false},
+	{"exception 3 skips double alternate when c is 6", "900003", "68646112", true},
+	{"exception 3 skips double alternate when c is 9", "900003", "80991813", true},
+	{"exception 3 applies double alternate otherwise", "900003", "31111310", false},
+	{"exception 4 remainder equals gh", "900004", "50376410", true},
+	{"exception 4 remainder does not equal gh", "900004", "10000000", false},
+	{"exception 5 both check digits correct", "900005", "62593654", true},
+	{"exception 5 second check digit incorrect", "900005", "84074206", false},
+	{"exception 5 sort code substituted", "900006", "62593654", true},
+	{"exception 6 foreign currency account is not checked", "900016", "41011166", true},
+	{"exception 6 domestic account fails", "900016", "31011166", false},
+	{"exception 7 g is 9 zeroes u to b", "900007", "14077791", true},
+	{"exception 8 uses sort code 090126", "900018", "14445888", true},
+	{"exception 8 fails with its own sort code", "900018", "41128357", false},
+	{"exception 9 second check uses sort code 309634", "900002", "01718513", true},
+	{"exception 10 and 11 first check passes", "900010", "47037103", true},
+	{"exception 10 and 11 second check passes", "900010", "34691402", true},
+	{"exception 10 ab is 09 and g is 9 zeroes u to b", "900010", "09000094", true},
+	{"exception 10 and 11 both checks fail", "900010", "10000000", false},
+	{"exception 12 and 13 first check passes", "900012", "34691402", true},
+	{"exception 12 and 13 second check passes", "900012", "59382804", true},
+	{"exception 12 and 13 both checks fail", "900012", "10000000", false},
+	{"exception 14 passes standard check", "900014", "22345701", true},
+	{"exception 14 passes after removing h", "900014", "16421700", true},
+	{"exception 14 h is not 0, 1 or 9", "900014", "55433473", false},
+
+	// Formats
+	{"sort code with dashes", "90-00-08", "75749118", true},
+	{"account number with spaces", "900008", "7574 9118", true},
+	{"sort code not in the table", "123456", "12345678", true},
+	{"six digit account number", "900009", "685995", false},
+	{"nine digit account number", "900000", "972247045", true},
+	{"ten digit account number", "900009", "1207924402", true},
+	{"ten digit co-operative account number", "080008", "3380141212", true},
+}
+
+func TestCheck(t *testing.T) {
+	m := testModulus(t)
+
+	for _, tc := range checkTC {
+		t.Run(tc.name, func(st *testing.T) {
+			err := m.Check(tc.sortCode, tc.account)
+			if tc.valid && err != nil {
+				st.Error("should pass the modulus check", cross, err)
+			}
+			if !tc.valid && err != ErrModulusCheck {
+				st.Error("should fail the modulus check", cross, err)
+			}
+		})
+	}
+}
+
+// publishedTC holds the test cases published by Vocalink alongside the weight table.
+var publishedTC = []struct {
+	name     string
+	sortCode string
+	account  string
+	valid    bool
+}{
+	{"pass modulus 10 check", "089999", "66374958", true},
+	{"pass modulus 11 check", "107999", "88837491", true},
+	{"pass modulus 11 and double alternate checks", "202959", "63748472", true},
+	{"exception 10 and 11 first check passes and second fails", "871427", "46238510", true},
+	{"exception 10 and 11 first check fails and second passes", "872427", "46238510", true},
+	{"exception 10 ab is 09 and g is 9", "871427", "09123496", true},
+	{"exception 10 ab is 99 and g is 9", "871427", "99123496", true},
+	{"exception 3 start of range c is 6 so second check ignored", "820000", "73688637", true},
+	{"exception 3 end of range c is 9 so second check ignored", "827999", "73988638", true},
+	{"exception 3 c is not 6 or 9 both checks pass", "827101", "28748352", true},
+	{"exception 4 remainder equals check digit", "134020", "63849203", true},
+	{"exception 1 adds 27 and passes double alternate check", "118765", "64371389", true},
+	{"exception 6 foreign currency account", "200915", "41011166", true},
+	{"exception 5 check passes", "938611", "07806039", true},
+	{"exception 5 check passes with substitution", "938600", "42368003", true},
+	{"exception 5 both checks have a remainder of 0", "938063", "55065200", true},
+	{"exception 7 passes but would fail the standard check", "772798", "99345694", true},
+	{"exception 8 sort code replaced with 090126", "086090", "06774744", true},
+	{"exception 2 and 9 first check passes", "309070", "02355688", true},
+	{"exception 2 and 9 first check fails second passes with substitution", "309070", "12345668", true},
+	{"exception 2 and 9 where a is not 0 and g is not 9", "309070", "12345677", true},
+	{"exception 2 and 9 where a is not 0 and g is 9", "309070", "99345694", true},
+	{"exception 5 first check digit correct second incorrect", "938063", "15764273", false},
+	{"exception 5 first check digit incorrect second correct", "938063", "15764264", false},
+	{"exception 5 first check digit incorrect with a remainder of 1", "938063", "15763217", false},
+	{"exception 1 fails double alternate check", "118765", "64371388", false},
+	{"pass modulus 11 and fail double alternate check", "203099", "66831036", false},
+	{"fail modulus 11 and pass double alternate check", "203099", "58716970", false},
+	{"fail modulus 10 check", "089999", "66374959", false},
+	{"fail modulus 11 check", "107999", "88837493", false},
+	{"exception 12 and 13 either check passes", "074456", "12345112", true},
+	{"exception 12 and 13 either check passes for another sort code", "070116", "34012583", true},
+	{"exception 12 and 13 either check passes for another account", "074456", "11104102", true},
+	{"exception 14 first check fails second passes", "180002", "00000190", true},
+}
+
+// TestCheckPublished runs the test cases published by Vocalink against the weight and
+// substitution tables published alongside them. The tables are not distributed with the
+// package, so the test is skipped unless valacdos.txt and scsubtab.txt have been
+// downloaded from Vocalink into the testdata directory.
+func TestCheckPublished(t *testing.T) {
+	weights := filepath.Join("testdata", "valacdos.txt")
+	subs := filepath.Join("testdata", "scsubtab.txt")
+	if _, err := os.Stat(weights); os.IsNotExist(err) {
+		t.Skip("the Vocalink weight table is not in the testdata directory")
+	}
+
+	m, err := LoadModulusFiles(weights, subs)
+	if err != nil {
+		t.Fatal("should load the published tables", cross, err)
+	}
+
+	for _, tc := range publishedTC {
+		t.Run(tc.name, func(st *testing.T) {
+			err := m.Check(tc.sortCode, tc.account)
+			if tc.valid && err != nil {
+				st.Error("should pass the modulus check", cross, err)
+			}
+			if !tc.valid && err != ErrModulusCheck {
+				st.Error("should fail the modulus check", cross, err)
+			}
+		})
+	}
+}
+
+func TestCheckWithoutSubstitution(t *testing.T) {
+	m, _ := LoadModulus(strings.NewReader(weightTable))
+
+	if err := m.Check("900006", "62593654"); err != ErrModulusCheck {
+		t.Error("should fail the modulus check without the substituted sort code", cross, err)
+	}
+}
+
+var checkFormatTC = []struct {
+	name     string
+	sortCode string
+	account  string
+	want     error
+}{
+	{"short sort code", "90000", "75749118", ErrInvalidSortCode},
+	{"sort code with letters", "90000A", "75749118", ErrInvalidSortCode},
+	{"short account number", "900008", "12345", ErrInvalidAccountNumber},
+	{"long account number", "900008", "12345678901", ErrInvalidAccountNumber},
+	{"account number with letters", "900008", "7574911A", ErrInvalidAccountNumber},
+}
+
+func TestCheckFormat(t *testing.T) {
+	m := testModulus(t)
+
+	for _, tc := range checkFormatTC {
+		t.Run(tc.name, func(st *testing.T) {
+			if err := m.Check(tc.sortCode, tc.account); err != tc.want {
+				st.Error("should reject the sort code or account number", cross, err)
+			}
+		})
+	}
+}
+
+var loadModulusTC = []struct {
+	name  string
+	table string
+}{
+	{"too few weights", "089999 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7"},
+	{"unknown algorithm", "089999 089999 MOD12 0 0 0 0 0 0 7 1 3 7 1 3 7 1"},
+	{"invalid sort code", "08999 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 1"},
+	{"invalid weight", "089999 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 X"},
+	{"invalid exception", "089999 089999 MOD10 0 0 0 0 0 0 7 1 3 7 1 3 7 1 15"},
+}
+
+func TestLoadModulusInvalid(t *testing.T) {
+	for _, tc := range loadModulusTC {
+		t.Run(tc.name, func(st *testing.T) {
+			if _, err := LoadModulus(strings.NewReader(tc.table)); err == nil {
+				st.Error("should reject the weight table", cross)
+			}
+		})
+	}
+}
+
+func TestLoadModulusFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "validation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	weights := filepath.Join(dir, "valacdos.txt")
+	subs := filepath.Join(dir, "scsubtab.txt")
+	ioutil.WriteFile(weights, []byte(weightTable), 0644)
+	ioutil.WriteFile(subs, []byte(substitutionTable), 0644)
+
+	m, err := LoadModulusFiles(weights, subs)
+	if err != nil {
+		t.Fatal("should load the tables", cross, err)
+	}
+
+	if err := m.Check("900006", "62593654"); err != nil {
+		t.Error("should use the substitution table", cross, err)
+	}
+
+	if _, err := LoadModulusFiles(filepath.Join(dir, "missing.txt"), ""); err == nil {
+		t.Error("should return an error for a missing weight table", cross)
+	}
+
+	if _, err := LoadModulusFiles(weights, filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("should return an error for a missing substitution table", cross)
+	}
+}